@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	addresscodec "github.com/Peersyst/xrpl-go/address-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/config"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/keystore"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/service"
+	"golang.org/x/term"
+)
+
+// Read the keystore passphrase from XRPL_KEYSTORE_PASSPHRASE, or prompt for it without echo
+func readPassphrase() (string, error) {
+	if passphrase := os.Getenv("XRPL_KEYSTORE_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("set XRPL_KEYSTORE_PASSPHRASE or run the signer in a terminal")
+	}
+
+	fmt.Fprint(os.Stderr, "Keystore passphrase: ")
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return string(passphrase), err
+}
+
+func main() {
+	// Initialize configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: go run ./cmd/signer <wallet>[:<account-address>]...")
+		fmt.Println("  Serves keystore wallets to remote signers at XRPL_SIGNER_URL, authorized by XRPL_SIGNER_TOKEN")
+		fmt.Println("  A wallet followed by an account address signs for that account as its regular key")
+		return
+	}
+	if cfg.SignerToken == "" {
+		log.Fatal("XRPL_SIGNER_TOKEN must be set to authorize requests to the signing service")
+	}
+	signerURL, err := url.Parse(cfg.SignerURL)
+	if err != nil || signerURL.Host == "" {
+		log.Fatalf("Invalid XRPL_SIGNER_URL %q", cfg.SignerURL)
+	}
+
+	store, err := keystore.Open(cfg.KeystoreDir)
+	if err != nil {
+		log.Fatalf("Failed to open keystore: %v", err)
+	}
+	passphrase, err := readPassphrase()
+	if err != nil {
+		log.Fatalf("Failed to read passphrase: %v", err)
+	}
+
+	// Check every wallet can be decrypted before serving any of them
+	signers := make(map[string]service.Signer)
+	for _, arg := range os.Args[1:] {
+		alias, account, _ := strings.Cut(arg, ":")
+		if account != "" && !addresscodec.IsValidClassicAddress(account) {
+			log.Fatalf("Invalid account address %q for wallet %s", account, alias)
+		}
+		if _, err := store.Export(alias, passphrase); err != nil {
+			log.Fatalf("Failed to unlock wallet: %v", err)
+		}
+		signer, err := store.Signer(alias, types.Address(account), func() (string, error) {
+			return passphrase, nil
+		})
+		if err != nil {
+			log.Fatalf("Failed to load wallet: %v", err)
+		}
+		signers[alias] = signer
+		log.Printf("Serving wallet %s, signing for account %s", alias, signer.Address())
+	}
+
+	server := &http.Server{
+		Addr:              signerURL.Host,
+		Handler:           service.NewSigningHandler(cfg.SignerToken, signers),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Shut down gracefully on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	// Requests are not encrypted, keep them off shared networks or put the signer behind a TLS proxy
+	if host, _, _ := net.SplitHostPort(signerURL.Host); host != "127.0.0.1" && host != "localhost" && host != "::1" {
+		log.Printf("Warning: the signing service listens on %s without TLS, only expose it through a TLS proxy", host)
+	}
+
+	log.Printf("Signing service started on %s", signerURL.Host)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+	log.Println("Signing service stopped")
+}
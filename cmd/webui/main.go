@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"math"
 	"net/http"
+	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
 	"github.com/Peersyst/xrpl-go/xrpl/wallet"
@@ -16,8 +25,25 @@ import (
 )
 
 // Helper function to import wallet from secret
-func walletFromSecret(secret string) (*wallet.Wallet, error) {
-	wallet, err := wallet.FromSecret(secret)
+func walletFromSecret(secret, account string) (*wallet.Wallet, error) {
+	// The secret may also be a mnemonic, optionally followed by the derivation path of the wallet
+	if words := strings.Fields(secret); len(words) > 1 {
+		path := ""
+		if last := words[len(words)-1]; strings.HasPrefix(last, "m/") {
+			path, words = last, words[:len(words)-1]
+		}
+		derived, err := service.WalletFromMnemonic(strings.Join(words, " "), path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import wallet from mnemonic: %w", err)
+		}
+		if account != "" {
+			derived.ClassicAddress = types.Address(account)
+		}
+		return derived, nil
+	}
+
+	// The secret may be the regular key of the given account
+	wallet, err := wallet.FromSeed(secret, account)
 	if err != nil {
 		return nil, fmt.Errorf("failed to import wallet from secret: %w", err)
 	}
@@ -29,6 +55,117 @@ func toAddress(address string) types.Address {
 	return types.Address(address)
 }
 
+// Apply a deadline to every request context
+func withTimeout(handler http.Handler, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Map a service error to an HTTP status code and an error code, using defaultCode for unclassified errors
+func errorStatus(err error, defaultCode string) (int, string) {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound, "ACCOUNT_NOT_FOUND"
+	case errors.Is(err, service.ErrInsufficientReserve):
+		return http.StatusUnprocessableEntity, "INSUFFICIENT_RESERVE"
+	case errors.Is(err, service.ErrNoTrustLine):
+		return http.StatusUnprocessableEntity, "NO_TRUST_LINE"
+	case errors.Is(err, service.ErrLineFrozen):
+		return http.StatusUnprocessableEntity, "LINE_FROZEN"
+	case errors.Is(err, service.ErrLineNotFrozen):
+		return http.StatusUnprocessableEntity, "LINE_NOT_FROZEN"
+	case errors.Is(err, service.ErrGlobalFreeze):
+		return http.StatusUnprocessableEntity, "GLOBAL_FREEZE"
+	case errors.Is(err, service.ErrRequiresAuthorization):
+		return http.StatusUnprocessableEntity, "REQUIRES_AUTHORIZATION"
+	case errors.Is(err, service.ErrAuthNotRequired):
+		return http.StatusUnprocessableEntity, "AUTH_NOT_REQUIRED"
+	case errors.Is(err, service.ErrClawbackNotEnabled):
+		return http.StatusUnprocessableEntity, "CLAWBACK_NOT_ENABLED"
+	case errors.Is(err, service.ErrNothingToClawBack):
+		return http.StatusUnprocessableEntity, "NOTHING_TO_CLAW_BACK"
+	case errors.Is(err, service.ErrPathDry):
+		return http.StatusUnprocessableEntity, "PATH_DRY"
+	case errors.Is(err, service.ErrNoTickets):
+		return http.StatusConflict, "NO_TICKETS"
+	case errors.Is(err, service.ErrFeeTooHigh):
+		return http.StatusServiceUnavailable, "FEE_TOO_HIGH"
+	case errors.Is(err, service.ErrMainnetNotConfirmed):
+		return http.StatusForbidden, "MAINNET_NOT_CONFIRMED"
+	case errors.Is(err, service.ErrConnectionLost):
+		return http.StatusBadGateway, "CONNECTION_LOST"
+	case errors.Is(err, service.ErrSignerUnavailable):
+		return http.StatusBadGateway, "SIGNER_UNAVAILABLE"
+	case errors.Is(err, service.ErrNotValidated), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, "NOT_VALIDATED"
+	}
+	return http.StatusInternalServerError, defaultCode
+}
+
+// Returned for requests naming a remote key without the web interface token
+var errRemoteKeyUnauthorized = errors.New("remote keys require the web interface token set by XRPL_WEBUI_TOKEN")
+
+// Check that a request carries the web interface token as a bearer token, always false if no token is configured
+func webUIAuthorized(r *http.Request, token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) == 1
+}
+
+// Status of a request whose signer could not be set up, refused credentials are not server errors
+func signerErrorStatus(err error) int {
+	if errors.Is(err, errRemoteKeyUnauthorized) {
+		return http.StatusUnauthorized
+	}
+	status, _ := errorStatus(err, "")
+	return status
+}
+
+// Write the outcome of a write operation, including the transaction result when it was submitted
+func writeTxResult(w http.ResponseWriter, result *service.TxResult, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		status, code := errorStatus(err, "TX_ERROR")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error":  "Transaction failed",
+			"detail": err.Error(),
+			"code":   code,
+			"result": result,
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"txHash": result.Hash,
+		"result": result,
+	})
+}
+
+// Write the outcome of every line of a bulk trust line update, failed lines do not fail the request
+func writeTrustLineResults(w http.ResponseWriter, lines []service.TrustLineResult) {
+	results := make([]map[string]any, 0, len(lines))
+	for _, line := range lines {
+		item := map[string]any{
+			"trustlineAddress": line.TrustlineAddress,
+			"result":           line.Result,
+		}
+		if line.Result != nil {
+			item["txHash"] = line.Result.Hash
+		}
+		if line.Err != nil {
+			_, code := errorStatus(line.Err, "TX_ERROR")
+			item["error"] = line.Err.Error()
+			item["code"] = code
+		}
+		results = append(results, item)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"results": results})
+}
+
 func main() {
 	// Initialize configuration
 	cfg, err := config.Load()
@@ -36,21 +173,93 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Shared service, keeps a single connection to the XRP Ledger for all handlers
+	xrplService := service.NewXRPLService(cfg)
+	defer xrplService.Close()
+
+	// Service for a request, building and checking transactions without submitting them for dry runs.
+	// Transactions are only submitted to mainnet for requests confirming it with the X-Confirm-Mainnet header.
+	serviceFor := func(r *http.Request, dryRun bool) *service.XRPLService {
+		requestService := xrplService
+		if r.Header.Get("X-Confirm-Mainnet") == "true" {
+			requestService = requestService.WithMainnetConfirmed()
+		}
+		if dryRun {
+			return requestService.WithDryRun()
+		}
+		return requestService
+	}
+
+	// Signer for a request: a key of the remote signing service, or a wallet imported from a secret
+	// unless remote signing is required so that no key enters this process
+	signerFor := func(r *http.Request, secret, account, remoteKey string) (service.Signer, error) {
+		if remoteKey != "" {
+			// The server's signer token must not sign for anyone who can reach the web interface
+			if !webUIAuthorized(r, cfg.WebUIToken) {
+				return nil, errRemoteKeyUnauthorized
+			}
+			return service.NewRemoteSigner(r.Context(), cfg.SignerURL, cfg.SignerToken, remoteKey)
+		}
+		if cfg.RemoteSigningOnly {
+			return nil, fmt.Errorf("secrets are not accepted, sign with a remoteKey of the signing service")
+		}
+		signerWallet, err := walletFromSecret(secret, account)
+		if err != nil {
+			return nil, err
+		}
+		return service.NewWalletSigner(signerWallet), nil
+	}
+
 	// Serve static files
 	fs := http.FileServer(http.Dir(filepath.Join("cmd", "webui", "static")))
 	http.Handle("/", fs)
 
 	// API endpoints
 	http.HandleFunc("/api/create-account", func(w http.ResponseWriter, r *http.Request) {
-		xrplService := service.NewXRPLService(cfg)
-		account, err := xrplService.CreateAccount()
+		// Options are optional, an empty request creates an ed25519 wallet
+		var options service.CreateAccountOptions
+		if err := json.NewDecoder(r.Body).Decode(&options); err != nil && err != io.EOF {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if options.Count > 100 {
+			http.Error(w, "at most 100 accounts can be derived at once", http.StatusBadRequest)
+			return
+		}
+
+		accounts, err := xrplService.CreateAccountWithOptions(&options)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 
+		// Wallets derived from a mnemonic have no seed, their secret is the mnemonic followed by the path
+		type createdAccount struct {
+			Address        types.Address `json:"address"`
+			Secret         string        `json:"secret"`
+			DerivationPath string        `json:"derivationPath,omitempty"`
+		}
+		created := make([]createdAccount, 0, len(accounts))
+		for _, account := range accounts {
+			secret := account.Wallet.Seed
+			if account.Mnemonic != "" {
+				secret = account.Mnemonic + " " + account.DerivationPath
+			}
+			created = append(created, createdAccount{
+				Address:        account.Wallet.ClassicAddress,
+				Secret:         secret,
+				DerivationPath: account.DerivationPath,
+			})
+		}
+
 		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"address":"%s","secret":"%s"}`, account.ClassicAddress, account.Seed)
+		json.NewEncoder(w).Encode(map[string]any{
+			"address":        created[0].Address,
+			"secret":         created[0].Secret,
+			"mnemonic":       accounts[0].Mnemonic,
+			"derivationPath": created[0].DerivationPath,
+			"accounts":       created,
+		})
 	})
 
 	http.HandleFunc("/api/fund-account", func(w http.ResponseWriter, r *http.Request) {
@@ -62,7 +271,8 @@ func main() {
 			return
 		}
 
-		success, err := service.FundDevnetAccount(toAddress(req.Address))
+		// Fund from the faucet of XRPL_FAUCET and wait until the payment is validated
+		funding, err := xrplService.FundAndWaitContext(r.Context(), toAddress(req.Address))
 		if err != nil {
 			errorResponse := map[string]string{
 				"error":  "Account funding failed",
@@ -76,14 +286,22 @@ func main() {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"success":%t}`, success)
+		json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"amount":  funding.Amount,
+			"balance": funding.Balance,
+			"txHash":  funding.TxHash,
+		})
 	})
 
 	// Configure issuer account
 	http.HandleFunc("/api/configure-issuer", func(w http.ResponseWriter, r *http.Request) {
 		var req struct {
-			Secret  string                  `json:"secret"`
-			Options service.AccountSetFlags `json:"options,omitempty"`
+			Secret    string                  `json:"secret"`
+			Account   string                  `json:"account"`
+			RemoteKey string                  `json:"remoteKey"`
+			Options   service.AccountSetFlags `json:"options,omitempty"`
+			DryRun    bool                    `json:"dryRun"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -91,29 +309,25 @@ func main() {
 		}
 
 		// Import wallet from secret
-		issuerWallet, err := walletFromSecret(req.Secret)
+		issuerSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to import issuer wallet: %v", err), http.StatusInternalServerError)
+			http.Error(w, fmt.Sprintf("Failed to import issuer wallet: %v", err), signerErrorStatus(err))
 			return
 		}
 
 		// Configure issuer account
-		xrplService := service.NewXRPLService(cfg)
-		txHash, err := xrplService.ConfigureIssuerAccount(issuerWallet, &req.Options)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"txHash":"%s"}`, txHash)
+		result, err := serviceFor(r, req.DryRun).ConfigureIssuerAccountContext(r.Context(), issuerSigner, &req.Options)
+		writeTxResult(w, result, err)
 	})
 
 	// Configure distributor account
 	http.HandleFunc("/api/configure-distributor", func(w http.ResponseWriter, r *http.Request) {
 		var req struct {
-			Secret  string                  `json:"secret"`
-			Options service.AccountSetFlags `json:"options,omitempty"`
+			Secret    string                  `json:"secret"`
+			Account   string                  `json:"account"`
+			RemoteKey string                  `json:"remoteKey"`
+			Options   service.AccountSetFlags `json:"options,omitempty"`
+			DryRun    bool                    `json:"dryRun"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -121,29 +335,25 @@ func main() {
 		}
 
 		// Import wallet from secret
-		distributorWallet, err := walletFromSecret(req.Secret)
+		distributorSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to import distributor wallet: %v", err), http.StatusInternalServerError)
+			http.Error(w, fmt.Sprintf("Failed to import distributor wallet: %v", err), signerErrorStatus(err))
 			return
 		}
 
 		// Configure distributor account
-		xrplService := service.NewXRPLService(cfg)
-		txHash, err := xrplService.ConfigureDistributorAccount(distributorWallet, &req.Options)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"txHash":"%s"}`, txHash)
+		result, err := serviceFor(r, req.DryRun).ConfigureDistributorAccountContext(r.Context(), distributorSigner, &req.Options)
+		writeTxResult(w, result, err)
 	})
 
 	// Create trust line
 	http.HandleFunc("/api/create-trustline", func(w http.ResponseWriter, r *http.Request) {
 		var req struct {
-			Secret  string                   `json:"secret"`
-			Options service.TrustLineOptions `json:"options"`
+			Secret    string                   `json:"secret"`
+			Account   string                   `json:"account"`
+			RemoteKey string                   `json:"remoteKey"`
+			Options   service.TrustLineOptions `json:"options"`
+			DryRun    bool                     `json:"dryRun"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -151,30 +361,27 @@ func main() {
 		}
 
 		// Import wallet from secret
-		receiverWallet, err := walletFromSecret(req.Secret)
+		receiverSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to import receiver wallet: %v", err), http.StatusInternalServerError)
+			http.Error(w, fmt.Sprintf("Failed to import receiver wallet: %v", err), signerErrorStatus(err))
 			return
 		}
 
 		// Create trust line
-		xrplService := service.NewXRPLService(cfg)
-		txHash, err := xrplService.CreateTrustLine(receiverWallet, &req.Options)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"txHash":"%s"}`, txHash)
+		result, err := serviceFor(r, req.DryRun).CreateTrustLineContext(r.Context(), receiverSigner, &req.Options)
+		writeTxResult(w, result, err)
 	})
 
 	// Freeze trust line
 	http.HandleFunc("/api/freeze-trustline", func(w http.ResponseWriter, r *http.Request) {
 		var req struct {
 			Secret           string `json:"secret"`
+			Account          string `json:"account"`
+			RemoteKey        string `json:"remoteKey"`
 			TrustlineAddress string `json:"trustlineAddress"`
 			TokenName        string `json:"tokenName"`
+			Deep             bool   `json:"deep"`
+			DryRun           bool   `json:"dryRun"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -182,30 +389,33 @@ func main() {
 		}
 
 		// Import wallet from secret
-		accountWallet, err := walletFromSecret(req.Secret)
+		accountSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to import account wallet: %v", err), http.StatusInternalServerError)
+			http.Error(w, fmt.Sprintf("Failed to import account wallet: %v", err), signerErrorStatus(err))
 			return
 		}
 
 		// Freeze trust line
-		xrplService := service.NewXRPLService(cfg)
-		txHash, err := xrplService.FreezeTrustLine(accountWallet, toAddress(req.TrustlineAddress), req.TokenName)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+		var result *service.TxResult
+		if req.Deep {
+			// Also stop the frozen line from receiving
+			result, err = serviceFor(r, req.DryRun).DeepFreezeTrustLineContext(r.Context(), accountSigner, toAddress(req.TrustlineAddress), req.TokenName)
+		} else {
+			result, err = serviceFor(r, req.DryRun).FreezeTrustLineContext(r.Context(), accountSigner, toAddress(req.TrustlineAddress), req.TokenName)
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"txHash":"%s"}`, txHash)
+		writeTxResult(w, result, err)
 	})
 
 	// Unfreeze trust line
 	http.HandleFunc("/api/unfreeze-trustline", func(w http.ResponseWriter, r *http.Request) {
 		var req struct {
 			Secret           string `json:"secret"`
+			Account          string `json:"account"`
+			RemoteKey        string `json:"remoteKey"`
 			TrustlineAddress string `json:"trustlineAddress"`
 			TokenName        string `json:"tokenName"`
+			Deep             bool   `json:"deep"`
+			DryRun           bool   `json:"dryRun"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -213,32 +423,301 @@ func main() {
 		}
 
 		// Import wallet from secret
-		accountWallet, err := walletFromSecret(req.Secret)
+		accountSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to import account wallet: %v", err), http.StatusInternalServerError)
+			http.Error(w, fmt.Sprintf("Failed to import account wallet: %v", err), signerErrorStatus(err))
 			return
 		}
 
 		// Unfreeze trust line
-		xrplService := service.NewXRPLService(cfg)
-		txHash, err := xrplService.UnfreezeTrustLine(accountWallet, toAddress(req.TrustlineAddress), req.TokenName)
+		var result *service.TxResult
+		if req.Deep {
+			// Lift only the deep freeze, the line stays frozen
+			result, err = serviceFor(r, req.DryRun).ClearDeepFreezeTrustLineContext(r.Context(), accountSigner, toAddress(req.TrustlineAddress), req.TokenName)
+		} else {
+			result, err = serviceFor(r, req.DryRun).UnfreezeTrustLineContext(r.Context(), accountSigner, toAddress(req.TrustlineAddress), req.TokenName)
+		}
+		writeTxResult(w, result, err)
+	})
+
+	// Freeze or unfreeze the trust lines with many counterparties, reporting the outcome of every line
+	freezeBatch := func(freeze bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Secret             string   `json:"secret"`
+				Account            string   `json:"account"`
+				RemoteKey          string   `json:"remoteKey"`
+				TrustlineAddresses []string `json:"trustlineAddresses"`
+				TokenName          string   `json:"tokenName"`
+				DryRun             bool     `json:"dryRun"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			// Import wallet from secret
+			accountSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Failed to import account wallet: %v", err), signerErrorStatus(err))
+				return
+			}
+
+			addresses := make([]types.Address, 0, len(req.TrustlineAddresses))
+			for _, address := range req.TrustlineAddresses {
+				addresses = append(addresses, toAddress(address))
+			}
+
+			writeTrustLineResults(w, serviceFor(r, req.DryRun).FreezeTrustLinesContext(r.Context(), accountSigner, addresses, req.TokenName, freeze))
+		}
+	}
+	http.HandleFunc("/api/freeze-trustline-batch", freezeBatch(true))
+	http.HandleFunc("/api/unfreeze-trustline-batch", freezeBatch(false))
+
+	// Authorize a holder's trust line for a token of an issuer requiring authorization
+	http.HandleFunc("/api/authorize-trustline", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret        string `json:"secret"`
+			Account       string `json:"account"`
+			RemoteKey     string `json:"remoteKey"`
+			HolderAddress string `json:"holderAddress"`
+			TokenName     string `json:"tokenName"`
+			DryRun        bool   `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		issuerSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import issuer wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		result, err := serviceFor(r, req.DryRun).AuthorizeTrustLineContext(r.Context(), issuerSigner, toAddress(req.HolderAddress), req.TokenName)
+		writeTxResult(w, result, err)
+	})
+
+	// Authorize the listed holders' trust lines, or every line waiting for authorization when allPending is set
+	http.HandleFunc("/api/authorize-trustline-batch", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret             string   `json:"secret"`
+			Account            string   `json:"account"`
+			RemoteKey          string   `json:"remoteKey"`
+			TrustlineAddresses []string `json:"trustlineAddresses"`
+			AllPending         bool     `json:"allPending"`
+			TokenName          string   `json:"tokenName"`
+			DryRun             bool     `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		issuerSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import issuer wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		addresses := make([]types.Address, 0, len(req.TrustlineAddresses))
+		for _, address := range req.TrustlineAddresses {
+			addresses = append(addresses, toAddress(address))
+		}
+		if req.AllPending {
+			pending, err := xrplService.PendingAuthorizationsContext(r.Context(), issuerSigner.Address(), req.TokenName)
+			if err != nil {
+				status, code := errorStatus(err, "PENDING_AUTHORIZATIONS_ERROR")
+				errorResponse := map[string]string{
+					"error":  "Failed to get pending authorizations",
+					"detail": err.Error(),
+					"code":   code,
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(errorResponse)
+				return
+			}
+			addresses = addresses[:0]
+			for _, line := range pending {
+				addresses = append(addresses, toAddress(line.Account))
+			}
+		}
+
+		writeTrustLineResults(w, serviceFor(r, req.DryRun).AuthorizeTrustLinesContext(r.Context(), issuerSigner, addresses, req.TokenName))
+	})
+
+	// Query the holder trust lines an issuer has not authorized yet, of every token when tokenName is empty
+	http.HandleFunc("/api/get-pending-authorizations", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Address   string `json:"address"`
+			TokenName string `json:"tokenName"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		pending, err := xrplService.PendingAuthorizationsContext(r.Context(), toAddress(req.Address), req.TokenName)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			status, code := errorStatus(err, "PENDING_AUTHORIZATIONS_ERROR")
+			errorResponse := map[string]string{
+				"error":  "Failed to get pending authorizations",
+				"detail": err.Error(),
+				"code":   code,
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(errorResponse)
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"txHash":"%s"}`, txHash)
+		json.NewEncoder(w).Encode(map[string]any{"lines": pending})
+	})
+
+	// Claw back tokens from a holder, reporting the holder's balance before and after
+	http.HandleFunc("/api/clawback", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret        string `json:"secret"`
+			Account       string `json:"account"`
+			RemoteKey     string `json:"remoteKey"`
+			HolderAddress string `json:"holderAddress"`
+			TokenName     string `json:"tokenName"`
+			Amount        string `json:"amount"`
+			DryRun        bool   `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		issuerSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import issuer wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		clawback, err := serviceFor(r, req.DryRun).ClawbackContext(r.Context(), issuerSigner, &service.ClawbackOptions{
+			HolderAddress: toAddress(req.HolderAddress),
+			TokenName:     req.TokenName,
+			Amount:        req.Amount,
+		})
+		if err != nil {
+			var result *service.TxResult
+			if clawback != nil {
+				result = clawback.TxResult
+			}
+			writeTxResult(w, result, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"txHash":        clawback.Hash,
+			"result":        clawback.TxResult,
+			"balanceBefore": clawback.BalanceBefore,
+			"balanceAfter":  clawback.BalanceAfter,
+		})
+	})
+
+	// Freeze or unfreeze all tokens of an issuer
+	http.HandleFunc("/api/global-freeze", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret    string `json:"secret"`
+			Account   string `json:"account"`
+			RemoteKey string `json:"remoteKey"`
+			Enabled   bool   `json:"enabled"`
+			DryRun    bool   `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		issuerSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import issuer wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		result, err := serviceFor(r, req.DryRun).SetGlobalFreezeContext(r.Context(), issuerSigner, req.Enabled)
+		writeTxResult(w, result, err)
+	})
+
+	// Permanently give up freezing, which cannot be undone and must be confirmed in the request
+	http.HandleFunc("/api/set-no-freeze", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret    string `json:"secret"`
+			Account   string `json:"account"`
+			RemoteKey string `json:"remoteKey"`
+			Confirm   bool   `json:"confirm"`
+			DryRun    bool   `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !req.Confirm && !req.DryRun {
+			http.Error(w, "NoFreeze cannot be cleared once set, send confirm: true to set it", http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		issuerSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import issuer wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		result, err := serviceFor(r, req.DryRun).SetNoFreezeContext(r.Context(), issuerSigner)
+		writeTxResult(w, result, err)
+	})
+
+	http.HandleFunc("/api/get-freeze-state", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Address string `json:"address"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		state, err := xrplService.GetFreezeStateContext(r.Context(), toAddress(req.Address))
+		if err != nil {
+			status, code := errorStatus(err, "FREEZE_STATE_ERROR")
+			errorResponse := map[string]string{
+				"error":  "Failed to get freeze state",
+				"detail": err.Error(),
+				"code":   code,
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(errorResponse)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(state)
 	})
 
 	http.HandleFunc("/api/transfer-token", func(w http.ResponseWriter, r *http.Request) {
 		var req struct {
 			SenderSecret    string `json:"senderSecret"`
+			SenderAccount   string `json:"senderAccount"`
+			SenderRemoteKey string `json:"senderRemoteKey"`
 			ReceiverAddress string `json:"receiverAddress"`
 			IssuerAddress   string `json:"issuerAddress"`
 			TokenName       string `json:"tokenName"`
 			TransferRate    string `json:"transferRate"`
 			Amount          string `json:"amount"`
+			UseTicket       bool   `json:"useTicket"`
+			DryRun          bool   `json:"dryRun"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -246,9 +725,9 @@ func main() {
 		}
 
 		// Import wallet from secret
-		senderWallet, err := walletFromSecret(req.SenderSecret)
+		senderSigner, err := signerFor(r, req.SenderSecret, req.SenderAccount, req.SenderRemoteKey)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to import sender wallet: %v", err), http.StatusInternalServerError)
+			http.Error(w, fmt.Sprintf("Failed to import sender wallet: %v", err), signerErrorStatus(err))
 			return
 		}
 
@@ -283,18 +762,377 @@ func main() {
 			TokenName:       req.TokenName,
 			Amount:          req.Amount,
 			SendMax:         sendMax,
+			UseTicket:       req.UseTicket,
 		}
 
 		// Transfer tokens
-		xrplService := service.NewXRPLService(cfg)
-		txHash, err := xrplService.TransferToken(senderWallet, transferToReceiverOptions)
+		result, err := serviceFor(r, req.DryRun).TransferTokenContext(r.Context(), senderSigner, transferToReceiverOptions)
+		writeTxResult(w, result, err)
+	})
+
+	// Transfer tokens to many receivers, pipelining payments from the sender
+	http.HandleFunc("/api/transfer-token-batch", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			SenderSecret    string `json:"senderSecret"`
+			SenderAccount   string `json:"senderAccount"`
+			SenderRemoteKey string `json:"senderRemoteKey"`
+			IssuerAddress   string `json:"issuerAddress"`
+			TokenName       string `json:"tokenName"`
+			UseTickets      bool   `json:"useTickets"`
+			Transfers       []struct {
+				ReceiverAddress string `json:"receiverAddress"`
+				Amount          string `json:"amount"`
+			} `json:"transfers"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		senderSigner, err := signerFor(r, req.SenderSecret, req.SenderAccount, req.SenderRemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import sender wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		transfers := make([]*service.TransferTokenOptions, 0, len(req.Transfers))
+		for _, transfer := range req.Transfers {
+			transfers = append(transfers, &service.TransferTokenOptions{
+				ReceiverAddress: toAddress(transfer.ReceiverAddress),
+				IssuerAddress:   toAddress(req.IssuerAddress),
+				TokenName:       req.TokenName,
+				Amount:          transfer.Amount,
+				UseTicket:       req.UseTickets,
+			})
+		}
+
+		// Report the outcome of every transfer, failed transfers do not fail the request
+		results := make([]map[string]any, 0, len(transfers))
+		for _, transfer := range serviceFor(r, false).TransferTokensContext(r.Context(), senderSigner, transfers) {
+			item := map[string]any{
+				"receiverAddress": transfer.Options.ReceiverAddress,
+				"amount":          transfer.Options.Amount,
+				"result":          transfer.Result,
+			}
+			if transfer.Result != nil {
+				item["txHash"] = transfer.Result.Hash
+			}
+			if transfer.Err != nil {
+				_, code := errorStatus(transfer.Err, "TX_ERROR")
+				item["error"] = transfer.Err.Error()
+				item["code"] = code
+			}
+			results = append(results, item)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"results": results})
+	})
+
+	// Create tickets for out-of-order submission
+	http.HandleFunc("/api/create-tickets", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret    string `json:"secret"`
+			Account   string `json:"account"`
+			RemoteKey string `json:"remoteKey"`
+			Count     uint32 `json:"count"`
+			DryRun    bool   `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		accountSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import account wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		result, tickets, err := serviceFor(r, req.DryRun).CreateTicketsContext(r.Context(), accountSigner, req.Count)
+		if err != nil {
+			writeTxResult(w, result, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"txHash":  result.Hash,
+			"result":  result,
+			"tickets": tickets,
+		})
+	})
+
+	http.HandleFunc("/api/get-tickets", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Address string `json:"address"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		tickets, err := xrplService.TicketsContext(r.Context(), toAddress(req.Address))
+		if err != nil {
+			status, code := errorStatus(err, "TICKETS_ERROR")
+			errorResponse := map[string]string{
+				"error":  "Failed to get tickets",
+				"detail": err.Error(),
+				"code":   code,
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(errorResponse)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"tickets": tickets})
+	})
+
+	// Install, replace or remove the signer list of an account
+	http.HandleFunc("/api/set-signer-list", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret    string                `json:"secret"`
+			Account   string                `json:"account"`
+			RemoteKey string                `json:"remoteKey"`
+			Quorum    uint32                `json:"quorum"`
+			Signers   []service.SignerEntry `json:"signers"`
+			DryRun    bool                  `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		accountSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import account wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		signerList := &service.SignerList{Quorum: req.Quorum, Signers: req.Signers}
+		if err := signerList.Validate(accountSigner.Address()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result, err := serviceFor(r, req.DryRun).SetSignerListContext(r.Context(), accountSigner, signerList)
+		writeTxResult(w, result, err)
+	})
+
+	http.HandleFunc("/api/get-signer-list", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Address string `json:"address"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		signerList, err := xrplService.SignerListContext(r.Context(), toAddress(req.Address))
+		if err != nil {
+			status, code := errorStatus(err, "SIGNER_LIST_ERROR")
+			errorResponse := map[string]string{
+				"error":  "Failed to get signer list",
+				"detail": err.Error(),
+				"code":   code,
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(errorResponse)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"signerList": signerList})
+	})
+
+	// Set, rotate or remove (empty regularKey) the regular key of an account
+	http.HandleFunc("/api/set-regular-key", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret     string `json:"secret"`
+			Account    string `json:"account"`
+			RemoteKey  string `json:"remoteKey"`
+			RegularKey string `json:"regularKey"`
+			DryRun     bool   `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		accountSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import account wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		result, err := serviceFor(r, req.DryRun).SetRegularKeyContext(r.Context(), accountSigner, toAddress(req.RegularKey))
+		writeTxResult(w, result, err)
+	})
+
+	// Disable or re-enable (disable false) the master key of an account
+	http.HandleFunc("/api/master-key", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret    string `json:"secret"`
+			Account   string `json:"account"`
+			RemoteKey string `json:"remoteKey"`
+			Disable   bool   `json:"disable"`
+			DryRun    bool   `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		accountSigner, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import account wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		var result *service.TxResult
+		if req.Disable {
+			result, err = serviceFor(r, req.DryRun).DisableMasterKeyContext(r.Context(), accountSigner)
+		} else {
+			result, err = serviceFor(r, req.DryRun).EnableMasterKeyContext(r.Context(), accountSigner)
+		}
+		writeTxResult(w, result, err)
+	})
+
+	http.HandleFunc("/api/get-account-keys", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Address string `json:"address"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		keys, err := xrplService.AccountKeysContext(r.Context(), toAddress(req.Address))
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			status, code := errorStatus(err, "ACCOUNT_KEYS_ERROR")
+			errorResponse := map[string]string{
+				"error":  "Failed to get account keys",
+				"detail": err.Error(),
+				"code":   code,
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(errorResponse)
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"txHash":"%s"}`, txHash)
+		json.NewEncoder(w).Encode(keys)
+	})
+
+	// Autofill any transaction and return it unsigned, prepared for multisigning when signers is set
+	http.HandleFunc("/api/prepare", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Transaction json.RawMessage `json:"transaction"`
+			Signers     uint32          `json:"signers"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		tx, err := service.ParseTransactionJSON(req.Transaction)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		prepareService := xrplService.WithPrepareOnly()
+		if req.Signers > 0 {
+			prepareService = xrplService.WithMultisignPrepare(req.Signers)
+		}
+		result, err := prepareService.PrepareTransactionContext(r.Context(), tx)
+		writeTxResult(w, result, err)
+	})
+
+	// Add one signer's signature to a prepared transaction, signed locally or by the signing service
+	http.HandleFunc("/api/multisign", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Secret    string `json:"secret"`
+			Account   string `json:"account"`
+			RemoteKey string `json:"remoteKey"`
+			TxBlob    string `json:"txBlob"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Import wallet from secret
+		signer, err := signerFor(r, req.Secret, req.Account, req.RemoteKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to import signer wallet: %v", err), signerErrorStatus(err))
+			return
+		}
+
+		signed, err := service.MultisignOffline(r.Context(), signer, req.TxBlob)
+		if err != nil {
+			// Unclassified errors come from the submitted transaction, signer outages map like other service errors
+			status, code := errorStatus(err, "MULTISIGN_ERROR")
+			if code == "MULTISIGN_ERROR" {
+				status = http.StatusBadRequest
+			}
+			errorResponse := map[string]string{
+				"error":  "Failed to multisign transaction",
+				"detail": err.Error(),
+				"code":   code,
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(errorResponse)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(signed)
+	})
+
+	// Merge signatures from several signers into a multisigned transaction ready for /api/submit
+	http.HandleFunc("/api/combine", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			TxBlobs []string `json:"txBlobs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		combined, err := service.CombineSignatures(req.TxBlobs...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(combined)
+	})
+
+	// Submit a transaction signed offline; the secret never reaches the server
+	http.HandleFunc("/api/submit", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			TxBlob string `json:"txBlob"`
+			DryRun bool   `json:"dryRun"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result, err := serviceFor(r, req.DryRun).SubmitSignedTransactionContext(r.Context(), req.TxBlob)
+		writeTxResult(w, result, err)
 	})
 
 	http.HandleFunc("/api/get-balance", func(w http.ResponseWriter, r *http.Request) {
@@ -306,22 +1144,22 @@ func main() {
 			return
 		}
 
-		xrplService := service.NewXRPLService(cfg)
-		balance, err := xrplService.GetBalance(toAddress(req.Address))
+		balance, err := xrplService.GetAccountBalanceContext(r.Context(), toAddress(req.Address))
 		if err != nil {
+			status, code := errorStatus(err, "BALANCE_ERROR")
 			errorResponse := map[string]string{
 				"error":  "Failed to get balance",
 				"detail": err.Error(),
-				"code":   "BALANCE_ERROR",
+				"code":   code,
 			}
 			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(status)
 			json.NewEncoder(w).Encode(errorResponse)
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"balance":"%s"}`, balance)
+		json.NewEncoder(w).Encode(balance)
 	})
 
 	http.HandleFunc("/api/get-tokens", func(w http.ResponseWriter, r *http.Request) {
@@ -333,16 +1171,16 @@ func main() {
 			return
 		}
 
-		xrplService := service.NewXRPLService(cfg)
-		tokens, err := xrplService.GetTokenBalances(toAddress(req.Address))
+		tokens, err := xrplService.GetAccountTokensContext(r.Context(), toAddress(req.Address))
 		if err != nil {
+			status, code := errorStatus(err, "TOKENS_ERROR")
 			errorResponse := map[string]string{
 				"error":  "Failed to get token balances",
 				"detail": err.Error(),
-				"code":   "TOKENS_ERROR",
+				"code":   code,
 			}
 			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(status)
 			json.NewEncoder(w).Encode(errorResponse)
 			return
 		}
@@ -362,7 +1200,7 @@ func main() {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"tokens":%s}`, tokensJSON)
+		w.Write(tokensJSON)
 	})
 
 	http.HandleFunc("/api/get-trustlines", func(w http.ResponseWriter, r *http.Request) {
@@ -374,16 +1212,16 @@ func main() {
 			return
 		}
 
-		xrplService := service.NewXRPLService(cfg)
-		trustlines, err := xrplService.GetAllTrustLines(toAddress(req.Address))
+		trustlines, err := xrplService.GetAllTrustLinesContext(r.Context(), toAddress(req.Address))
 		if err != nil {
+			status, code := errorStatus(err, "TRUSTLINES_ERROR")
 			errorResponse := map[string]string{
 				"error":  "Failed to get trust lines",
 				"detail": err.Error(),
-				"code":   "TRUSTLINES_ERROR",
+				"code":   code,
 			}
 			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(status)
 			json.NewEncoder(w).Encode(errorResponse)
 			return
 		}
@@ -406,9 +1244,62 @@ func main() {
 		w.Write(trustlinesJSON)
 	})
 
+	// XRPL node pool health and the node currently in use
+	http.HandleFunc("/api/nodes", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]any{
+			"activeNode": xrplService.ActiveNode(),
+			"nodes":      xrplService.NodeStatus(r.URL.Query().Get("refresh") == "true"),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	// Network profile and how the active node compares with it
+	http.HandleFunc("/api/network", func(w http.ResponseWriter, r *http.Request) {
+		checks, err := xrplService.CheckNetworkContext(r.Context())
+		if err != nil {
+			status, code := errorStatus(err, "NETWORK_ERROR")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]any{
+				"error":  "Failed to query the active node",
+				"detail": err.Error(),
+				"code":   code,
+			})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"name":       xrplService.Network().String(),
+			"network":    xrplService.Network(),
+			"faucet":     cfg.Faucet,
+			"activeNode": xrplService.ActiveNode(),
+			"checks":     checks,
+		})
+	})
+
 	// Start server
 	port := cfg.Port
+	server := &http.Server{Addr: ":" + port, Handler: http.DefaultServeMux}
+	if cfg.RequestTimeout > 0 {
+		server.Handler = withTimeout(http.DefaultServeMux, cfg.RequestTimeout)
+	}
+
+	// Shut down gracefully on interrupt so the XRPL connection is closed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
 
 	log.Printf("Web interface started, visit http://localhost:%s to view", port)
-	log.Fatal(http.ListenAndServe(":"+port, nil))
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+	log.Println("Web interface stopped")
 }
@@ -668,17 +668,29 @@ function openTab(evt, tabName) {
 }
 
 // API通用函数
-async function fetchApi(endpoint, data = {}) {
+async function fetchApi(endpoint, data = {}, confirmMainnet = false) {
+  const headers = {
+    'Content-Type': 'application/json',
+  };
+  // 主网交易需要明确确认
+  if (confirmMainnet) {
+    headers['X-Confirm-Mainnet'] = 'true';
+  }
   const response = await fetch('/api/' + endpoint, {
     method: 'POST',
-    headers: {
-      'Content-Type': 'application/json',
-    },
+    headers,
     body: JSON.stringify(data),
   });
 
   const responseData = await response.json();
 
+  // 服务端拒绝未确认的主网交易时，向用户确认后重新提交
+  if (responseData.code === 'MAINNET_NOT_CONFIRMED' && !confirmMainnet) {
+    if (window.confirm(translations[currentLang]['confirm-mainnet'])) {
+      return fetchApi(endpoint, data, true);
+    }
+  }
+
   if (!response.ok) {
     // 处理详细的错误信息
     const errorMsg = responseData.detail
@@ -694,10 +706,26 @@ async function fetchApi(endpoint, data = {}) {
   return responseData;
 }
 
+// 当前网络的交易浏览器，默认使用DevNet浏览器，页面加载后从服务端获取
+let explorerURL = 'https://devnet.xrpl.org';
+
+// 获取当前网络配置
+async function loadNetwork() {
+  try {
+    const response = await fetch('/api/network');
+    if (!response.ok) {
+      return;
+    }
+    const network = await response.json();
+    explorerURL = network.network.explorerUrl || '';
+  } catch (error) {
+    console.error('Failed to load network:', error);
+  }
+}
+
 // 获取XRPL交易浏览器URL
 function getXRPLExplorerURL(txHash) {
-  // 默认使用DevNet浏览器，如果需要针对不同网络设置不同URL可以进一步扩展
-  return `https://devnet.xrpl.org/transactions/${txHash}`;
+  return `${explorerURL}/transactions/${txHash}`;
 }
 
 // 显示结果通用函数
@@ -708,7 +736,8 @@ function showResult(elementId, message, type = '') {
   const txHashRegex = /交易哈希: ([A-F0-9]+)|Transaction hash: ([A-F0-9]+)|トランザクションハッシュ: ([A-F0-9]+)/;
   const match = message.match(txHashRegex);
 
-  if (match) {
+  // 本地账本没有交易浏览器，不显示链接
+  if (match && explorerURL) {
     // 提取交易哈希
     const txHash = match[1] || match[2] || match[3];
     const url = getXRPLExplorerURL(txHash);
@@ -743,13 +772,11 @@ async function fundAccount() {
     const result = await fetchApi('fund-account', { address });
     showResult('fundResult', translations[currentLang]['funding-success'], 'success');
 
-    // 如果是已保存的账户，自动刷新其余额
+    // 如果是已保存的账户，自动刷新其余额（接口在充值交易验证后才返回）
     const accounts = getSavedAccounts();
     const accountIndex = accounts.findIndex((acc) => acc.address === address);
     if (accountIndex !== -1) {
-      setTimeout(() => {
-        refreshAccountBalance(accountIndex);
-      }, 5000); // 5秒后刷新余额，给系统处理充值的时间
+      refreshAccountBalance(accountIndex);
     }
   } catch (error) {
     const errorMsg = error.message ? error.message : String(error);
@@ -1604,6 +1631,9 @@ document.addEventListener('DOMContentLoaded', function () {
     });
   });
 
+  // 获取网络配置，用于交易浏览器链接
+  loadNetwork();
+
   // 初始化账户和选择菜单
   initializeAccountsFromStorage();
   updateSelectMenus();
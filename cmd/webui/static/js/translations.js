@@ -140,6 +140,7 @@ const translations = {
     'tick-size-range': '报价精度必须在0-15之间',
 
     // 交易链接和开发者信息
+    'confirm-mainnet': '当前连接的是主网，交易将动用真实资金。确定要提交吗？',
     'view-transaction': '查看交易',
     'copy-failed': '复制失败',
     'refresh-balance-failed': '刷新余额失败',
@@ -305,6 +306,7 @@ const translations = {
     'tick-size-range': '価格精度は0〜15の間である必要があります',
 
     // 交易链接和开发者信息
+    'confirm-mainnet': 'メインネットに接続しています。取引には実際の資金が使われます。送信しますか？',
     'view-transaction': '取引を見る',
     'copy-failed': 'コピー失敗',
     'refresh-balance-failed': '残高更新失敗',
@@ -472,6 +474,7 @@ const translations = {
     'tick-size-range': 'Tick size must be between 0-15',
 
     // 交易链接和开发者信息
+    'confirm-mainnet': 'You are connected to mainnet and this transaction moves real funds. Submit it?',
     'view-transaction': 'View Transaction',
     'copy-failed': 'Copy failed',
     'refresh-balance-failed': 'Refresh balance failed',
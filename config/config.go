@@ -3,21 +3,64 @@ package config
 import (
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/Peersyst/xrpl-go/xrpl/websocket"
 	"github.com/joho/godotenv"
 )
 
 // Config contains application configuration information
 type Config struct {
-	// Node URL, can be testnet or mainnet
+	// Network profile the nodes belong to, with an empty name for nodes matching no profile
+	Network Network
+	// Node URL, can be testnet or mainnet (primary node of NodeURLs)
 	NodeURL string
-	// Client instance
-	Client *websocket.Client
+	// All node URLs in failover priority order
+	NodeURLs []string
+	// Node pool with websocket clients and health status
+	Nodes *NodePool
+	// Deadline for a single operation including validation wait, zero means no deadline
+	RequestTimeout time.Duration
+	// Fixed transaction fee in drops, zero means follow the open ledger fee
+	FeeDrops uint64
+	// Multiplier applied to the open ledger fee when no fixed fee is set
+	FeeMultiplier float64
+	// Maximum transaction fee in drops, transactions costing more are refused; zero means no limit
+	MaxFeeDrops uint64
+	// Directory of the encrypted wallet keystore
+	KeystoreDir string
+	// URL of the remote signing service and the token authorizing requests to it
+	SignerURL   string
+	SignerToken string
+	// Whether the web interface refuses secrets and only signs with keys of the remote signing service
+	RemoteSigningOnly bool
+	// Bearer token web interface clients must present to sign with keys of the remote signing service,
+	// without it no remote key is usable through the web interface
+	WebUIToken string
+	// Faucet funding test accounts: devnet, testnet, genesis or the URL of a faucet service
+	Faucet string
+	// Secret of the account the genesis faucet pays from and the amount it pays in drops
+	GenesisSecret     string
+	FaucetAmountDrops uint64
+	// Interval at which ledgers are closed on a standalone rippled, zero when the network closes them
+	LedgerAcceptInterval time.Duration
 	// Application listening port
 	Port string
 }
 
+// Faucets selectable with XRPL_FAUCET besides the URL of a faucet service
+const (
+	FaucetDevnet  = "devnet"
+	FaucetTestnet = "testnet"
+	// Pays from an account of the ledger itself, such as the genesis account of a standalone rippled
+	FaucetGenesis = "genesis"
+)
+
+// Secret of the genesis account holding all XRP of a new standalone rippled ledger
+const standaloneGenesisSecret = "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"
+
 // Load loads application configuration
 func Load() (*Config, error) {
 	// Try to load environment variables from .env file
@@ -26,18 +69,69 @@ func Load() (*Config, error) {
 		log.Println("Warning: .env file not found or cannot be loaded, will use system environment variables or default values")
 	}
 
-	// Get node URL environment variable
-	nodeURL := os.Getenv("XRPL_NODE_URL")
-	if nodeURL == "" {
-		// If environment variable is not set, use XRP Ledger Devnet
-		nodeURL = "wss://s.devnet.rippletest.net:51233"
+	// Get node URL list, comma separated, falling back to the single node URL
+	nodeURLs := splitList(os.Getenv("XRPL_NODE_URLS"))
+	if len(nodeURLs) == 0 {
+		nodeURLs = splitList(os.Getenv("XRPL_NODE_URL"))
+	}
+
+	// Network profile, default is the network of the configured nodes or XRP Ledger Devnet
+	network, err := selectNetwork(os.Getenv("XRPL_NETWORK"), nodeURLs)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodeURLs) == 0 {
+		nodeURLs = network.NodeURLs
+	}
+
+	// Health check interval for the node pool, default is 30 seconds
+	checkInterval := durationEnv("XRPL_HEALTH_CHECK_INTERVAL", 30*time.Second)
+
+	// Maximum validated ledger age before a node is considered unhealthy, default is 20 seconds
+	maxLedgerAge := durationEnv("XRPL_MAX_LEDGER_AGE", 20*time.Second)
+
+	// Deadline for a single operation, default is no deadline
+	requestTimeout := durationEnv("XRPL_REQUEST_TIMEOUT", 0)
+
+	// Fee policy, default is the open ledger fee capped at 0.1 XRP
+	feeDrops := uintEnv("XRPL_FEE_DROPS", 0)
+	feeMultiplier := floatEnv("XRPL_FEE_MULTIPLIER", 1)
+	maxFeeDrops := uintEnv("XRPL_MAX_FEE_DROPS", 100000)
+
+	// Keystore directory, default is .xrpl-token-demo/keystore in the home directory
+	keystoreDir := os.Getenv("XRPL_KEYSTORE_DIR")
+	if keystoreDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = "."
+		}
+		keystoreDir = filepath.Join(home, ".xrpl-token-demo", "keystore")
+	}
+
+	// Remote signing service, default is a signer running on the same host
+	signerURL := os.Getenv("XRPL_SIGNER_URL")
+	if signerURL == "" {
+		signerURL = "http://127.0.0.1:8090"
 	}
 
-	// Create XRPL WebSocket client configuration
-	clientConfig := websocket.NewClientConfig().WithHost(nodeURL)
+	// Faucet, default is the faucet of the network; mainnet and unknown networks have none
+	faucet := os.Getenv("XRPL_FAUCET")
+	switch {
+	case faucet == "":
+		faucet = network.Faucet
+	case faucet == FaucetDevnet || faucet == FaucetTestnet || faucet == FaucetGenesis:
+	case strings.HasPrefix(faucet, "http://") || strings.HasPrefix(faucet, "https://"):
+	default:
+		log.Printf("Warning: invalid XRPL_FAUCET value %q, using the %s network faucet", faucet, network)
+		faucet = network.Faucet
+	}
 
-	// Create XRPL client
-	client := websocket.NewClient(clientConfig)
+	// Genesis account of a standalone rippled, paying 1000 XRP per account by default
+	genesisSecret := os.Getenv("XRPL_GENESIS_SECRET")
+	if genesisSecret == "" {
+		genesisSecret = standaloneGenesisSecret
+	}
+	faucetAmountDrops := uintEnv("XRPL_FAUCET_AMOUNT_DROPS", 1000000000)
 
 	// Get application port, default is 8080
 	port := os.Getenv("APP_PORT")
@@ -46,8 +140,95 @@ func Load() (*Config, error) {
 	}
 
 	return &Config{
-		NodeURL: nodeURL,
-		Client:  client,
-		Port:    port,
+		Network:           network,
+		NodeURL:           nodeURLs[0],
+		NodeURLs:          nodeURLs,
+		Nodes:             NewNodePool(nodeURLs, checkInterval, maxLedgerAge),
+		RequestTimeout:    requestTimeout,
+		FeeDrops:          feeDrops,
+		FeeMultiplier:     feeMultiplier,
+		MaxFeeDrops:       maxFeeDrops,
+		KeystoreDir:       keystoreDir,
+		SignerURL:         signerURL,
+		SignerToken:       os.Getenv("XRPL_SIGNER_TOKEN"),
+		RemoteSigningOnly: boolEnv("XRPL_REMOTE_SIGNING_ONLY", false),
+		WebUIToken:        os.Getenv("XRPL_WEBUI_TOKEN"),
+		Faucet:            faucet,
+		GenesisSecret:     genesisSecret,
+		FaucetAmountDrops: faucetAmountDrops,
+		// Ledgers only close on request in standalone mode, default is not to close them
+		LedgerAcceptInterval: durationEnv("XRPL_LEDGER_ACCEPT_INTERVAL", 0),
+		Port:                 port,
 	}, nil
 }
+
+// Split a comma separated list, dropping empty entries
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Read a duration environment variable, using the default value if unset or invalid
+func durationEnv(name string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Warning: invalid %s value %q, using default %s", name, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// Read an unsigned integer environment variable, using the default value if unset or invalid
+func uintEnv(name string, defaultValue uint64) uint64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		log.Printf("Warning: invalid %s value %q, using default %d", name, value, defaultValue)
+		return defaultValue
+	}
+	return number
+}
+
+// Read a floating point environment variable, using the default value if unset or invalid
+func floatEnv(name string, defaultValue float64) float64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		log.Printf("Warning: invalid %s value %q, using default %g", name, value, defaultValue)
+		return defaultValue
+	}
+	return number
+}
+
+// Read a boolean environment variable, using the default value if unset or invalid
+func boolEnv(name string, defaultValue bool) bool {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: invalid %s value %q, using default %t", name, value, defaultValue)
+		return defaultValue
+	}
+	return flag
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Network is a named XRP Ledger network and the settings that go with it
+type Network struct {
+	// Profile name, empty for nodes that match no profile
+	Name string `json:"name"`
+	// Public nodes, in failover priority order
+	NodeURLs []string `json:"nodeUrls"`
+	// Faucet funding test accounts, see XRPL_FAUCET; empty if the network has none
+	Faucet string `json:"faucet,omitempty"`
+	// Network ID reported by its nodes, zero for mainnet and standalone ledgers
+	NetworkID uint32 `json:"networkId"`
+	// Explorer showing accounts and transactions, empty if there is none
+	ExplorerURL string `json:"explorerUrl,omitempty"`
+	// Expected base and owner reserves in drops, zero when they are not known in advance
+	ReserveBaseDrops      uint64 `json:"reserveBaseDrops,omitempty"`
+	ReserveIncrementDrops uint64 `json:"reserveIncrementDrops,omitempty"`
+	// Whether XRP on the network has real value, so that write operations need explicit confirmation
+	Mainnet bool `json:"mainnet"`
+}
+
+// Networks are the network profiles selectable with XRPL_NETWORK
+var Networks = map[string]Network{
+	"mainnet": {
+		Name:                  "mainnet",
+		NodeURLs:              []string{"wss://xrplcluster.com", "wss://s1.ripple.com", "wss://s2.ripple.com"},
+		ExplorerURL:           "https://livenet.xrpl.org",
+		ReserveBaseDrops:      1000000,
+		ReserveIncrementDrops: 200000,
+		Mainnet:               true,
+	},
+	"testnet": {
+		Name:                  "testnet",
+		NodeURLs:              []string{"wss://s.altnet.rippletest.net:51233", "wss://testnet.xrpl-labs.com"},
+		Faucet:                FaucetTestnet,
+		NetworkID:             1,
+		ExplorerURL:           "https://testnet.xrpl.org",
+		ReserveBaseDrops:      1000000,
+		ReserveIncrementDrops: 200000,
+	},
+	"devnet": {
+		Name:                  "devnet",
+		NodeURLs:              []string{"wss://s.devnet.rippletest.net:51233"},
+		Faucet:                FaucetDevnet,
+		NetworkID:             2,
+		ExplorerURL:           "https://devnet.xrpl.org",
+		ReserveBaseDrops:      1000000,
+		ReserveIncrementDrops: 200000,
+	},
+	// Standalone rippled on this host, funded from its genesis account; reserves follow its configuration
+	"local": {
+		Name:     "local",
+		NodeURLs: []string{"ws://127.0.0.1:6006"},
+		Faucet:   FaucetGenesis,
+	},
+}
+
+// Name of the network used when neither XRPL_NETWORK nor node URLs are set
+const defaultNetwork = "devnet"
+
+// NetworkNames returns the names of the network profiles in alphabetical order
+func NetworkNames() []string {
+	names := make([]string, 0, len(Networks))
+	for name := range Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Select the network profile named by XRPL_NETWORK, or the profile whose public nodes include the
+// configured nodes so that pointing XRPL_NODE_URL at a mainnet node is still treated as mainnet.
+// Every configured node that is a public node of a profile must belong to the selected profile.
+func selectNetwork(name string, nodeURLs []string) (Network, error) {
+	var selected Network
+	if name != "" {
+		network, ok := Networks[strings.ToLower(name)]
+		if !ok {
+			return Network{}, fmt.Errorf("unknown network %q, use one of %s", name, strings.Join(NetworkNames(), ", "))
+		}
+		selected = network
+	} else if len(nodeURLs) == 0 {
+		return Networks[defaultNetwork], nil
+	}
+
+	for _, url := range nodeURLs {
+		network, ok := profileOf(url)
+		switch {
+		case !ok:
+			// Other nodes are checked against the network ID they report before submitting to them
+		case selected.Name == "":
+			selected = network
+		case network.Name != selected.Name:
+			return Network{}, fmt.Errorf("node %s is a %s node, not a %s node", url, network, selected)
+		}
+	}
+	return selected, nil
+}
+
+// Find the network profile whose public nodes include url
+func profileOf(url string) (Network, bool) {
+	for _, network := range Networks {
+		if network.Includes(url) {
+			return network, true
+		}
+	}
+	return Network{}, false
+}
+
+// Includes reports whether url is one of the public nodes of the network
+func (n Network) Includes(url string) bool {
+	for _, node := range n.NodeURLs {
+		if strings.EqualFold(strings.TrimSuffix(url, "/"), node) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the profile name, or custom for nodes matching no profile
+func (n Network) String() string {
+	if n.Name == "" {
+		return "custom"
+	}
+	return n.Name
+}
+
+// TransactionURL returns the explorer page of a transaction, empty if the network has no explorer
+func (n Network) TransactionURL(hash string) string {
+	if n.ExplorerURL == "" || hash == "" {
+		return ""
+	}
+	return n.ExplorerURL + "/transactions/" + hash
+}
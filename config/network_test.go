@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSelectNetwork tests that every configured node must belong to the selected network profile
+func TestSelectNetwork(t *testing.T) {
+	network, err := selectNetwork("", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "devnet", network.Name)
+
+	// Any public mainnet node in the pool selects mainnet
+	network, err = selectNetwork("", []string{"wss://private.example.com", "wss://s2.ripple.com/"})
+	require.NoError(t, err)
+	assert.True(t, network.Mainnet)
+
+	network, err = selectNetwork("", []string{"wss://private.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "custom", network.String())
+
+	network, err = selectNetwork("Testnet", []string{"wss://private.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "testnet", network.Name)
+
+	_, err = selectNetwork("testnet", []string{"wss://s.altnet.rippletest.net:51233", "wss://xrplcluster.com"})
+	assert.ErrorContains(t, err, "mainnet node")
+	_, err = selectNetwork("", []string{"wss://s.devnet.rippletest.net:51233", "wss://s1.ripple.com"})
+	assert.Error(t, err)
+	_, err = selectNetwork("moonnet", nil)
+	assert.Error(t, err)
+}
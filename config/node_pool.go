@@ -0,0 +1,240 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+// NodeStatus describes the health of a single XRPL node
+type NodeStatus struct {
+	URL             string    `json:"url"`             // Node websocket URL
+	Healthy         bool      `json:"healthy"`         // Whether the node passed its last health check
+	Checked         bool      `json:"checked"`         // Whether the node has been health checked yet
+	LatencyMS       int64     `json:"latencyMs"`       // server_info round trip time in milliseconds
+	ValidatedLedger uint      `json:"validatedLedger"` // Latest validated ledger index reported by the node
+	LedgerAge       uint      `json:"ledgerAge"`       // Age of the latest validated ledger in seconds
+	ServerState     string    `json:"serverState"`     // rippled server state (full, syncing, ...)
+	CheckedAt       time.Time `json:"checkedAt"`       // Time of the last health check
+	Error           string    `json:"error,omitempty"` // Reason the node is considered unhealthy
+}
+
+// NodePool holds the configured XRPL nodes, their websocket clients and health status.
+// Nodes are ordered by priority; failover picks the first healthy node in that order.
+type NodePool struct {
+	urls          []string
+	checkInterval time.Duration
+	maxLedgerAge  time.Duration
+
+	mu            sync.RWMutex
+	clients       map[string]*websocket.Client
+	healthClients map[string]*websocket.Client
+	status        map[string]*NodeStatus
+
+	checkMu   sync.Mutex
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+}
+
+// NewNodePool creates a pool for the given node URLs
+func NewNodePool(urls []string, checkInterval, maxLedgerAge time.Duration) *NodePool {
+	pool := &NodePool{
+		urls:          urls,
+		checkInterval: checkInterval,
+		maxLedgerAge:  maxLedgerAge,
+		clients:       make(map[string]*websocket.Client),
+		healthClients: make(map[string]*websocket.Client),
+		status:        make(map[string]*NodeStatus),
+		stop:          make(chan struct{}),
+	}
+	for _, url := range urls {
+		pool.status[url] = &NodeStatus{URL: url, Healthy: true}
+	}
+	return pool
+}
+
+// URLs returns the configured node URLs in priority order
+func (p *NodePool) URLs() []string {
+	return append([]string(nil), p.urls...)
+}
+
+// Client returns the shared websocket client for a node, creating it on first use
+func (p *NodePool) Client(url string) *websocket.Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	client, ok := p.clients[url]
+	if !ok {
+		client = newClient(url)
+		p.clients[url] = client
+	}
+	return client
+}
+
+// Candidates returns node URLs to try, healthy nodes first, in priority order
+func (p *NodePool) Candidates() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	candidates := make([]string, 0, len(p.urls))
+	for _, url := range p.urls {
+		if p.status[url].Healthy {
+			candidates = append(candidates, url)
+		}
+	}
+	for _, url := range p.urls {
+		if !p.status[url].Healthy {
+			candidates = append(candidates, url)
+		}
+	}
+	return candidates
+}
+
+// IsHealthy reports whether a node is currently considered healthy
+func (p *NodePool) IsHealthy(url string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	status, ok := p.status[url]
+	return ok && status.Healthy
+}
+
+// MarkUnhealthy flags a node as unhealthy until its next successful health check
+func (p *NodePool) MarkUnhealthy(url string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if status, ok := p.status[url]; ok {
+		status.Healthy = false
+		if err != nil {
+			status.Error = err.Error()
+		}
+	}
+}
+
+// Status returns a snapshot of the health of every node in priority order
+func (p *NodePool) Status() []NodeStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	result := make([]NodeStatus, 0, len(p.urls))
+	for _, url := range p.urls {
+		result = append(result, *p.status[url])
+	}
+	return result
+}
+
+// Start begins periodic health checks in the background.
+// Health checks are skipped for a single node pool since there is nothing to fail over to.
+func (p *NodePool) Start() {
+	if len(p.urls) < 2 || p.checkInterval <= 0 {
+		return
+	}
+
+	p.startOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(p.checkInterval)
+			defer ticker.Stop()
+
+			p.CheckHealth()
+			for {
+				select {
+				case <-ticker.C:
+					p.CheckHealth()
+				case <-p.stop:
+					return
+				}
+			}
+		}()
+	})
+}
+
+// Stop stops background health checks and closes health check connections
+func (p *NodePool) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for _, client := range p.healthClients {
+			if client.IsConnected() {
+				client.Disconnect()
+			}
+		}
+	})
+}
+
+// CheckHealth checks every node concurrently and updates its status
+func (p *NodePool) CheckHealth() {
+	p.checkMu.Lock()
+	defer p.checkMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, url := range p.urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			status := p.checkNode(url)
+
+			p.mu.Lock()
+			p.status[url] = &status
+			p.mu.Unlock()
+		}(url)
+	}
+	wg.Wait()
+}
+
+// Run server_info against a node using its dedicated health check client
+func (p *NodePool) checkNode(url string) NodeStatus {
+	status := NodeStatus{URL: url, Checked: true, CheckedAt: time.Now()}
+
+	p.mu.Lock()
+	client, ok := p.healthClients[url]
+	if !ok {
+		client = newClient(url)
+		p.healthClients[url] = client
+	}
+	p.mu.Unlock()
+
+	if !client.IsConnected() {
+		if err := client.Connect(); err != nil {
+			status.Error = err.Error()
+			return status
+		}
+	}
+
+	start := time.Now()
+	info, err := client.GetServerInfo(&server.InfoRequest{})
+	if err != nil {
+		// Drop the connection so the next check starts fresh
+		client.Disconnect()
+		status.Error = err.Error()
+		return status
+	}
+	status.LatencyMS = time.Since(start).Milliseconds()
+	status.ValidatedLedger = info.Info.ValidatedLedger.Seq
+	status.LedgerAge = info.Info.ValidatedLedger.Age
+	status.ServerState = info.Info.ServerState
+
+	switch {
+	case status.ValidatedLedger == 0:
+		status.Error = "node has no validated ledger"
+	case p.maxLedgerAge > 0 && time.Duration(status.LedgerAge)*time.Second > p.maxLedgerAge:
+		status.Error = "validated ledger is too old"
+	default:
+		status.Healthy = true
+	}
+	return status
+}
+
+// Create a websocket client for a node
+func newClient(url string) *websocket.Client {
+	client := websocket.NewClient(websocket.NewClientConfig().WithHost(url))
+	// Drain read loop errors so the client's reader goroutine can exit after a disconnect;
+	// dropped connections are detected when the next request fails
+	client.OnError(func(err error) {})
+	return client
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -34,56 +35,17 @@ func TestAccountLifecycle(t *testing.T) {
 	distributorAddress := distributorWallet.ClassicAddress
 	t.Logf("Distributor account created successfully: %s", distributorAddress)
 
-	// 3. Fund accounts
+	// 3. Fund accounts and wait for the payments to be validated
+	ctx := context.Background()
 	t.Log("3.1 Fund issuer account")
-	success, err := service.FundDevnetAccount(issuerAddress)
+	issuerFunding, err := xrplService.FundAndWaitContext(ctx, issuerAddress)
 	require.NoError(t, err, "Failed to fund issuer account")
-	assert.True(t, success)
+	t.Logf("Issuer account balance: %s (payment %s)", issuerFunding.Balance, issuerFunding.TxHash)
 
 	t.Log("3.2 Fund distributor account")
-	success, err = service.FundDevnetAccount(distributorAddress)
+	distributorFunding, err := xrplService.FundAndWaitContext(ctx, distributorAddress)
 	require.NoError(t, err, "Failed to fund distributor account")
-	assert.True(t, success)
-
-	// 4. Wait for funding to arrive
-	t.Log("4. Wait for funding to arrive")
-	time.Sleep(10 * time.Second)
-
-	// Check XRP balance of both accounts
-	const maxWaitTime = 120 * time.Second
-	const retryInterval = 5 * time.Second
-	startTime := time.Now()
-
-	// Wait for issuer account balance to arrive
-	var issuerBalance string
-	var balanceFound bool
-	for time.Since(startTime) < maxWaitTime {
-		issuerBalance, err = xrplService.GetBalance(issuerAddress)
-		if err == nil {
-			balanceFound = true
-			t.Logf("Issuer account balance: %s", issuerBalance)
-			break
-		}
-		t.Logf("Failed to get issuer balance, retrying in 5 seconds: %v", err)
-		time.Sleep(retryInterval)
-	}
-	require.True(t, balanceFound, "Failed to get issuer account balance within the specified time")
-
-	// Wait for distributor account balance to arrive
-	startTime = time.Now()
-	balanceFound = false
-	var distributorBalance string
-	for time.Since(startTime) < maxWaitTime {
-		distributorBalance, err = xrplService.GetBalance(distributorAddress)
-		if err == nil {
-			balanceFound = true
-			t.Logf("Distributor account balance: %s", distributorBalance)
-			break
-		}
-		t.Logf("Failed to get distributor balance, retrying in 5 seconds: %v", err)
-		time.Sleep(retryInterval)
-	}
-	require.True(t, balanceFound, "Failed to get distributor account balance within the specified time")
+	t.Logf("Distributor account balance: %s (payment %s)", distributorFunding.Balance, distributorFunding.TxHash)
 
 	// 5. Configure issuer account
 	t.Log("5. Configure issuer account settings")
@@ -94,7 +56,7 @@ func TestAccountLifecycle(t *testing.T) {
 		TickSize:            0,
 		SetAsfDefaultRipple: true,
 	}
-	_, err = xrplService.ConfigureIssuerAccount(issuerWallet, issuerOptions)
+	_, err = xrplService.ConfigureIssuerAccount(service.NewWalletSigner(issuerWallet), issuerOptions)
 	require.NoError(t, err, "Failed to configure issuer account settings")
 
 	// 6. Configure distributor account
@@ -103,7 +65,7 @@ func TestAccountLifecycle(t *testing.T) {
 		Domain:            "6578616D706C652E636F6D", // example.com
 		SetAsfRequireAuth: true,
 	}
-	_, err = xrplService.ConfigureDistributorAccount(distributorWallet, distributorOptions)
+	_, err = xrplService.ConfigureDistributorAccount(service.NewWalletSigner(distributorWallet), distributorOptions)
 	require.NoError(t, err, "Failed to configure distributor account settings")
 
 	// 7. Create distributor trust line
@@ -118,7 +80,7 @@ func TestAccountLifecycle(t *testing.T) {
 	}
 
 	_, err = xrplService.CreateTrustLine(
-		distributorWallet,
+		service.NewWalletSigner(distributorWallet),
 		trustLineOptions,
 	)
 	require.NoError(t, err, "Failed to create trust line")
@@ -134,7 +96,7 @@ func TestAccountLifecycle(t *testing.T) {
 	}
 
 	_, err = xrplService.TransferToken(
-		issuerWallet,
+		service.NewWalletSigner(issuerWallet),
 		transferOptions,
 	)
 	require.NoError(t, err, "Failed to issue tokens")
@@ -168,29 +130,9 @@ func TestAccountLifecycle(t *testing.T) {
 
 	// 10. Fund third-party receiver account with XRP
 	t.Log("10.2 Fund third-party receiver account with XRP")
-	success, err = service.FundDevnetAccount(receiverAddress)
+	receiverFunding, err := xrplService.FundAndWaitContext(ctx, receiverAddress)
 	require.NoError(t, err, "Failed to fund third-party receiver account")
-	assert.True(t, success)
-
-	// Wait for third-party account funding to arrive
-	t.Log("Wait for third-party account funding to arrive")
-	time.Sleep(10 * time.Second)
-
-	// Check third-party account's XRP balance
-	startTime = time.Now()
-	balanceFound = false
-	var receiverBalance string
-	for time.Since(startTime) < maxWaitTime {
-		receiverBalance, err = xrplService.GetBalance(receiverAddress)
-		if err == nil {
-			balanceFound = true
-			t.Logf("Third-party receiver account balance: %s", receiverBalance)
-			break
-		}
-		t.Logf("Failed to get third-party receiver account balance, retrying in 5 seconds: %v", err)
-		time.Sleep(retryInterval)
-	}
-	require.True(t, balanceFound, "Failed to get third-party receiver account balance within the specified time")
+	t.Logf("Third-party receiver account balance: %s (payment %s)", receiverFunding.Balance, receiverFunding.TxHash)
 
 	// 11. Create trust line from third-party receiver to issuer
 	t.Log("11. Create trust line from third-party receiver to issuer")
@@ -201,7 +143,7 @@ func TestAccountLifecycle(t *testing.T) {
 	}
 
 	_, err = xrplService.CreateTrustLine(
-		receiverWallet,
+		service.NewWalletSigner(receiverWallet),
 		receiverTrustLineOptions,
 	)
 	require.NoError(t, err, "Failed to create third-party receiver trust line")
@@ -231,7 +173,7 @@ func TestAccountLifecycle(t *testing.T) {
 	}
 
 	_, err = xrplService.TransferToken(
-		distributorWallet,
+		service.NewWalletSigner(distributorWallet),
 		transferToReceiverOptions,
 	)
 	require.NoError(t, err, "Failed to transfer tokens from distributor to third-party receiver")
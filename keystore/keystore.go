@@ -0,0 +1,352 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/pkg/crypto"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/service"
+	"golang.org/x/crypto/scrypt"
+)
+
+var (
+	// ErrWalletNotFound is returned when no wallet is stored under an alias
+	ErrWalletNotFound = errors.New("wallet not found in keystore")
+	// ErrWalletExists is returned when creating or importing a wallet under an alias already in use
+	ErrWalletExists = errors.New("wallet already exists in keystore")
+	// ErrWrongPassphrase is returned when a wallet cannot be decrypted with the given passphrase
+	ErrWrongPassphrase = errors.New("wrong passphrase")
+)
+
+// Key derivation parameters, scrypt with the cost recommended for interactive logins
+const (
+	scryptN    = 1 << 15
+	scryptR    = 8
+	scryptP    = 1
+	keyLength  = 32
+	saltLength = 32
+)
+
+const (
+	// Wallet file format version
+	fileVersion = 1
+	fileSuffix  = ".json"
+	// Minimum passphrase length
+	minPassLen = 8
+	// Wallet files and the keystore directory are only accessible to their owner
+	fileMode     = 0o600
+	keystoreMode = 0o700
+)
+
+// Aliases name a wallet file, so they are limited to characters safe in file names
+var aliasPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// Store is a directory of passphrase-encrypted wallets, one file per alias
+type Store struct {
+	dir     string
+	scryptN int
+}
+
+// Entry describes a stored wallet without decrypting it
+type Entry struct {
+	Alias     string        `json:"alias"`
+	Address   types.Address `json:"address"`
+	PublicKey string        `json:"publicKey"`
+	CreatedAt time.Time     `json:"createdAt"`
+	// BIP44 path of a wallet derived from a mnemonic, empty for wallets of a seed
+	DerivationPath string `json:"derivationPath,omitempty"`
+}
+
+// Wallet file contents; only the seed or mnemonic is encrypted, bound to the address as additional data
+type walletFile struct {
+	Version int `json:"version"`
+	Entry
+	Crypto cryptoParams `json:"crypto"`
+}
+
+type cryptoParams struct {
+	KDF        string    `json:"kdf"`
+	KDFParams  kdfParams `json:"kdfparams"`
+	Cipher     string    `json:"cipher"`
+	Nonce      string    `json:"nonce"`
+	Ciphertext string    `json:"ciphertext"`
+}
+
+type kdfParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"`
+}
+
+// Open opens the keystore in dir, creating the directory if needed
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, keystoreMode); err != nil {
+		return nil, fmt.Errorf("unable to create keystore directory: %w", err)
+	}
+	return &Store{dir: dir, scryptN: scryptN}, nil
+}
+
+// Dir returns the keystore directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Create generates a new ed25519 wallet and stores it under alias
+func (s *Store) Create(alias, passphrase string) (*Entry, error) {
+	newWallet, err := wallet.New(crypto.ED25519())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create wallet: %w", err)
+	}
+	return s.Import(alias, newWallet.Seed, passphrase)
+}
+
+// Import stores the wallet of an existing secret under alias, encrypted with passphrase
+func (s *Store) Import(alias, secret, passphrase string) (*Entry, error) {
+	imported, err := wallet.FromSecret(strings.TrimSpace(secret))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret: %w", err)
+	}
+	return s.store(alias, imported.Seed, "", &imported, passphrase)
+}
+
+// ImportMnemonic stores the wallet at a BIP44 path of a mnemonic under alias, encrypted with passphrase.
+// An empty path selects the first account of the mnemonic.
+func (s *Store) ImportMnemonic(alias, mnemonic, path, passphrase string) (*Entry, error) {
+	if path == "" {
+		path = service.DefaultDerivationPath
+	}
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	derived, err := service.WalletFromMnemonic(mnemonic, path)
+	if err != nil {
+		return nil, err
+	}
+	return s.store(alias, mnemonic, path, derived, passphrase)
+}
+
+// Encrypt the seed or mnemonic of a wallet into a new wallet file
+func (s *Store) store(alias, secret, path string, imported *wallet.Wallet, passphrase string) (*Entry, error) {
+	if !ValidAlias(alias) {
+		return nil, fmt.Errorf("invalid alias %q, use up to 64 letters, digits, '_', '.' or '-'", alias)
+	}
+	if len(passphrase) < minPassLen {
+		return nil, fmt.Errorf("passphrase must be at least %d characters", minPassLen)
+	}
+	if s.Has(alias) {
+		return nil, fmt.Errorf("%w: %s", ErrWalletExists, alias)
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	params := kdfParams{N: s.scryptN, R: scryptR, P: scryptP, Salt: hex.EncodeToString(salt)}
+	aead, err := newAEAD(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	file := walletFile{
+		Version: fileVersion,
+		Entry: Entry{
+			Alias:          alias,
+			Address:        imported.ClassicAddress,
+			PublicKey:      imported.PublicKey,
+			CreatedAt:      time.Now().UTC(),
+			DerivationPath: path,
+		},
+		Crypto: cryptoParams{
+			KDF:        "scrypt",
+			KDFParams:  params,
+			Cipher:     "aes-256-gcm",
+			Nonce:      hex.EncodeToString(nonce),
+			Ciphertext: hex.EncodeToString(aead.Seal(nil, nonce, []byte(secret), []byte(imported.ClassicAddress))),
+		},
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	// Never overwrite an existing wallet, even one created concurrently
+	f, err := os.OpenFile(s.path(alias), os.O_WRONLY|os.O_CREATE|os.O_EXCL, fileMode)
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("%w: %s", ErrWalletExists, alias)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to write wallet file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(s.path(alias))
+		return nil, fmt.Errorf("unable to write wallet file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("unable to write wallet file: %w", err)
+	}
+	return &file.Entry, nil
+}
+
+// Has reports whether a wallet is stored under alias
+func (s *Store) Has(alias string) bool {
+	if !ValidAlias(alias) {
+		return false
+	}
+	_, err := os.Stat(s.path(alias))
+	return err == nil
+}
+
+// List returns the stored wallets sorted by alias
+func (s *Store) List() ([]Entry, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+fileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(paths))
+	for _, path := range paths {
+		file, err := readWalletFile(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, file.Entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Alias < entries[j].Alias
+	})
+	return entries, nil
+}
+
+// Lookup returns the stored wallet of alias without decrypting it
+func (s *Store) Lookup(alias string) (*Entry, error) {
+	if !s.Has(alias) {
+		return nil, fmt.Errorf("%w: %s", ErrWalletNotFound, alias)
+	}
+	file, err := readWalletFile(s.path(alias))
+	if err != nil {
+		return nil, err
+	}
+	return &file.Entry, nil
+}
+
+// Unlock decrypts the wallet stored under alias
+func (s *Store) Unlock(alias, passphrase string) (*wallet.Wallet, error) {
+	entry, err := s.Lookup(alias)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.Export(alias, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.DerivationPath != "" {
+		unlocked, err := service.WalletFromMnemonic(secret, entry.DerivationPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to restore wallet %s: %w", alias, err)
+		}
+		return unlocked, nil
+	}
+	unlocked, err := wallet.FromSecret(secret)
+	if err != nil {
+		return nil, fmt.Errorf("unable to restore wallet %s: %w", alias, err)
+	}
+	return &unlocked, nil
+}
+
+// Export decrypts and returns the secret of the wallet stored under alias, its seed or the mnemonic it is derived from
+func (s *Store) Export(alias, passphrase string) (string, error) {
+	if !s.Has(alias) {
+		return "", fmt.Errorf("%w: %s", ErrWalletNotFound, alias)
+	}
+	file, err := readWalletFile(s.path(alias))
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(passphrase, file.Crypto.KDFParams)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := hex.DecodeString(file.Crypto.Nonce)
+	if err != nil {
+		return "", fmt.Errorf("invalid nonce in wallet file %s: %w", alias, err)
+	}
+	ciphertext, err := hex.DecodeString(file.Crypto.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("invalid ciphertext in wallet file %s: %w", alias, err)
+	}
+	seed, err := aead.Open(nil, nonce, ciphertext, []byte(file.Address))
+	if err != nil {
+		return "", fmt.Errorf("%w for wallet %s", ErrWrongPassphrase, alias)
+	}
+	return string(seed), nil
+}
+
+// Delete removes the wallet stored under alias
+func (s *Store) Delete(alias string) error {
+	if !s.Has(alias) {
+		return fmt.Errorf("%w: %s", ErrWalletNotFound, alias)
+	}
+	return os.Remove(s.path(alias))
+}
+
+// ValidAlias reports whether alias can name a wallet
+func ValidAlias(alias string) bool {
+	return aliasPattern.MatchString(alias)
+}
+
+// Path of the wallet file of an alias
+func (s *Store) path(alias string) string {
+	return filepath.Join(s.dir, alias+fileSuffix)
+}
+
+// Read and check a wallet file
+func readWalletFile(path string) (*walletFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read wallet file: %w", err)
+	}
+	var file walletFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid wallet file %s: %w", path, err)
+	}
+	if file.Version != fileVersion || file.Crypto.KDF != "scrypt" || file.Crypto.Cipher != "aes-256-gcm" {
+		return nil, fmt.Errorf("unsupported wallet file %s", path)
+	}
+	return &file, nil
+}
+
+// Derive the encryption key from the passphrase and set up AES-GCM
+func newAEAD(passphrase string, params kdfParams) (cipher.AEAD, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
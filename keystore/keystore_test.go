@@ -0,0 +1,181 @@
+package keystore
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/pkg/crypto"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Open a keystore in a temporary directory with a cheap key derivation
+func openTestStore(t *testing.T) *Store {
+	store, err := Open(t.TempDir())
+	require.NoError(t, err)
+	store.scryptN = 1 << 10
+	return store
+}
+
+// TestKeystoreLifecycle tests creating, importing, listing, unlocking, exporting and deleting wallets
+func TestKeystoreLifecycle(t *testing.T) {
+	store := openTestStore(t)
+	passphrase := "correct horse battery"
+
+	created, err := store.Create("issuer", passphrase)
+	require.NoError(t, err)
+
+	existing, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+	imported, err := store.Import("distributor", existing.Seed, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, existing.ClassicAddress, imported.Address)
+
+	_, err = store.Import("issuer", existing.Seed, passphrase)
+	assert.ErrorIs(t, err, ErrWalletExists)
+
+	entries, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "distributor", entries[0].Alias)
+	assert.Equal(t, created.Address, entries[1].Address)
+
+	entry, err := store.Lookup("issuer")
+	require.NoError(t, err)
+	assert.Equal(t, created.Address, entry.Address)
+
+	unlocked, err := store.Unlock("distributor", passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, existing.PrivateKey, unlocked.PrivateKey)
+
+	secret, err := store.Export("distributor", passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, existing.Seed, secret)
+
+	_, err = store.Unlock("issuer", "wrong passphrase")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	require.NoError(t, store.Delete("issuer"))
+	_, err = store.Unlock("issuer", passphrase)
+	assert.ErrorIs(t, err, ErrWalletNotFound)
+	assert.ErrorIs(t, store.Delete("issuer"), ErrWalletNotFound)
+}
+
+// TestKeystoreFile tests that wallet files are private and never contain the secret in plain text
+func TestKeystoreFile(t *testing.T) {
+	store := openTestStore(t)
+	existing, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+	_, err = store.Import("issuer", existing.Seed, "correct horse battery")
+	require.NoError(t, err)
+
+	info, err := os.Stat(store.path("issuer"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	data, err := os.ReadFile(store.path("issuer"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), existing.Seed)
+	assert.NotContains(t, string(data), existing.PrivateKey)
+}
+
+// TestKeystoreMnemonic tests storing and unlocking a wallet derived from a mnemonic
+func TestKeystoreMnemonic(t *testing.T) {
+	store := openTestStore(t)
+	mnemonic, err := service.NewMnemonic()
+	require.NoError(t, err)
+	derived, err := service.WalletFromMnemonic(mnemonic, "m/44'/144'/0'/0/1")
+	require.NoError(t, err)
+
+	entry, err := store.ImportMnemonic("derived", mnemonic, "m/44'/144'/0'/0/1", "correct horse battery")
+	require.NoError(t, err)
+	assert.Equal(t, derived.ClassicAddress, entry.Address)
+	assert.Equal(t, "m/44'/144'/0'/0/1", entry.DerivationPath)
+
+	unlocked, err := store.Unlock("derived", "correct horse battery")
+	require.NoError(t, err)
+	assert.Equal(t, derived.ClassicAddress, unlocked.ClassicAddress)
+	assert.Equal(t, derived.PrivateKey, unlocked.PrivateKey)
+
+	exported, err := store.Export("derived", "correct horse battery")
+	require.NoError(t, err)
+	assert.Equal(t, mnemonic, exported)
+
+	data, err := os.ReadFile(store.path("derived"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), strings.Fields(mnemonic)[0]+" ")
+
+	_, err = store.ImportMnemonic("invalid", "not a mnemonic", "", "correct horse battery")
+	assert.Error(t, err)
+}
+
+// TestKeystoreValidation tests alias, passphrase and secret checks
+func TestKeystoreValidation(t *testing.T) {
+	store := openTestStore(t)
+
+	_, err := store.Create("../issuer", "correct horse battery")
+	assert.Error(t, err)
+	_, err = store.Create("issuer", "short")
+	assert.Error(t, err)
+	_, err = store.Import("issuer", "not a secret", "correct horse battery")
+	assert.Error(t, err)
+	assert.False(t, store.Has("issuer"))
+}
+
+// TestKeystoreSigner tests signing with a keystore wallet, as its own account and as another account's regular key
+func TestKeystoreSigner(t *testing.T) {
+	store := openTestStore(t)
+	passphrase := "correct horse battery"
+	key, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+	_, err = store.Import("regular", key.Seed, passphrase)
+	require.NoError(t, err)
+
+	var signer service.Signer
+	signer, err = store.Signer("regular", "", func() (string, error) { return passphrase, nil })
+	require.NoError(t, err)
+	assert.Equal(t, key.ClassicAddress, signer.Address())
+	assert.Equal(t, key.PublicKey, signer.PublicKey())
+
+	tx := transaction.FlatTransaction{
+		"TransactionType": "AccountSet",
+		"Account":         key.ClassicAddress.String(),
+		"Fee":             "12",
+		"Sequence":        uint32(1),
+	}
+	_, _, err = signer.Sign(context.Background(), tx)
+	require.NoError(t, err)
+	assert.Equal(t, key.PublicKey, tx["SigningPubKey"])
+
+	// Multisign as a signer of another account, matching the wallet held in memory
+	account, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+	newMultisignTx := func() transaction.FlatTransaction {
+		return transaction.FlatTransaction{
+			"TransactionType": "AccountSet",
+			"Account":         account.ClassicAddress.String(),
+			"Fee":             "24",
+			"Sequence":        uint32(1),
+			"SigningPubKey":   "",
+		}
+	}
+	multisigned, _, err := signer.Multisign(context.Background(), newMultisignTx())
+	require.NoError(t, err)
+	expected, _, err := key.Multisign(newMultisignTx())
+	require.NoError(t, err)
+	assert.Equal(t, expected, multisigned)
+
+	signer, err = store.Signer("regular", account.ClassicAddress, func() (string, error) { return "wrong passphrase", nil })
+	require.NoError(t, err)
+	assert.Equal(t, account.ClassicAddress, signer.Address())
+	_, _, err = signer.Sign(context.Background(), tx)
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	_, err = store.Signer("missing", "", nil)
+	assert.ErrorIs(t, err, ErrWalletNotFound)
+}
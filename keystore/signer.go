@@ -0,0 +1,74 @@
+package keystore
+
+import (
+	"context"
+
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+)
+
+// Signer signs with a keystore wallet, decrypting it for each signature so the private key
+// is only held in memory while signing
+type Signer struct {
+	store      *Store
+	entry      Entry
+	account    types.Address
+	passphrase func() (string, error)
+}
+
+// Signer returns a signer for the wallet stored under alias, calling passphrase for every signature.
+// With a non-empty account the wallet signs for that account as its regular key.
+func (s *Store) Signer(alias string, account types.Address, passphrase func() (string, error)) (*Signer, error) {
+	entry, err := s.Lookup(alias)
+	if err != nil {
+		return nil, err
+	}
+	if account == "" {
+		account = entry.Address
+	}
+	return &Signer{store: s, entry: *entry, account: account, passphrase: passphrase}, nil
+}
+
+// Address returns the account the wallet signs for
+func (s *Signer) Address() types.Address {
+	return s.account
+}
+
+// PublicKey returns the public key of the wallet
+func (s *Signer) PublicKey() string {
+	return s.entry.PublicKey
+}
+
+// Sign decrypts the wallet and signs tx with it
+func (s *Signer) Sign(_ context.Context, tx transaction.FlatTransaction) (string, string, error) {
+	unlocked, err := s.unlock()
+	if err != nil {
+		return "", "", err
+	}
+	return unlocked.Sign(tx)
+}
+
+// Multisign decrypts the wallet and signs tx with it as a signer of the transaction's account
+func (s *Signer) Multisign(_ context.Context, tx transaction.FlatTransaction) (string, string, error) {
+	unlocked, err := s.unlock()
+	if err != nil {
+		return "", "", err
+	}
+	return unlocked.Multisign(tx)
+}
+
+// Decrypt the wallet for a single signature
+func (s *Signer) unlock() (*wallet.Wallet, error) {
+	passphrase, err := s.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	unlocked, err := s.store.Unlock(s.entry.Alias, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	// Sign for the account even when the wallet is its regular key
+	unlocked.ClassicAddress = s.account
+	return unlocked, nil
+}
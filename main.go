@@ -1,14 +1,27 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
+	addresscodec "github.com/Peersyst/xrpl-go/address-codec"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
 	"github.com/Peersyst/xrpl-go/xrpl/wallet"
 	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/config"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/keystore"
 	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/service"
+	"golang.org/x/term"
 )
 
 func main() {
@@ -19,6 +32,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	keystoreDir = cfg.KeystoreDir
+	network = cfg.Network
+	signerURL, signerToken = cfg.SignerURL, cfg.SignerToken
 
 	// Execute different operations based on command line arguments
 	if len(os.Args) < 2 {
@@ -26,91 +42,291 @@ func main() {
 		return
 	}
 
+	// Signing and keystore management need no network access, handle them before connecting
+	switch os.Args[1] {
+	case "wallet":
+		manageWallets()
+		return
+	case "sign":
+		signPreparedTransaction()
+		return
+	case "multisign":
+		multisignPreparedTransaction()
+		return
+	case "combine":
+		combineSignatures()
+		return
+	}
+
 	xrplService := service.NewXRPLService(cfg)
+	defer xrplService.Close()
+
+	// Build, sign and check transactions without submitting them
+	if hasFlag("--dry-run") {
+		xrplService = xrplService.WithDryRun()
+	}
+
+	// Transactions on mainnet move real funds, write commands refuse to submit them unless confirmed
+	if hasFlag("--confirm-mainnet") {
+		xrplService = xrplService.WithMainnetConfirmed()
+	}
+
+	// Prepare unsigned transactions for offline signing, the command to prepare follows with the
+	// account address or wallet alias in place of its secret
+	if os.Args[1] == "prepare" {
+		if len(os.Args) < 3 || !preparableCommands[os.Args[2]] {
+			fmt.Println("Usage: go run main.go prepare <config-issuer|config-distributor|create-trustline|freeze-trustline|unfreeze-trustline|authorize-trustline|clawback|global-freeze|global-unfreeze|set-no-freeze|transfer-token|create-tickets|set-signer-list> <account-address-or-wallet> [arguments...] [--signers <count>]")
+			return
+		}
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		prepareOnly = true
+
+		// Prepare for multisigning when the number of signers is given
+		if value := flagValue("--signers"); value != "" {
+			signers, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || signers == 0 {
+				log.Fatalf("Invalid number of signers %q", value)
+			}
+			xrplService = xrplService.WithMultisignPrepare(uint32(signers))
+		} else {
+			xrplService = xrplService.WithPrepareOnly()
+		}
+	}
+
+	// Cancel pending requests, including waits for validation, on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if cfg.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.RequestTimeout)
+		defer cancel()
+	}
 
 	switch os.Args[1] {
 	case "create-account":
-		wallet, err := xrplService.CreateAccount()
+		options := &service.CreateAccountOptions{
+			Algorithm:      flagValue("--algorithm"),
+			Mnemonic:       hasFlag("--mnemonic"),
+			DerivationPath: flagValue("--path"),
+		}
+		if count := flagValue("--count"); count != "" {
+			n, err := strconv.Atoi(count)
+			if err != nil || n < 1 {
+				log.Fatalf("Invalid account count %q", count)
+			}
+			options.Count = n
+		}
+		if hasFlag("--restore") {
+			mnemonic, err := readHidden("Mnemonic: ")
+			if err != nil {
+				log.Fatalf("Failed to read mnemonic: %v", err)
+			}
+			options.RestoreMnemonic = mnemonic
+		}
+
+		accounts, err := xrplService.CreateAccountWithOptions(options)
 		if err != nil {
 			log.Fatalf("Failed to create account: %v", err)
 		}
-		fmt.Printf("Account created successfully!\nAddress: %s\nSecret: %s\n", wallet.ClassicAddress, wallet.PrivateKey)
 
-	case "fund-devnet-account":
+		// Store the new wallets encrypted in the keystore, under their address unless an alias is given,
+		// numbered when several are derived
+		for i, account := range accounts {
+			alias := account.Wallet.ClassicAddress.String()
+			if args := positionalArgs(); len(args) > 2 {
+				alias = args[2]
+				if len(accounts) > 1 {
+					alias = fmt.Sprintf("%s-%d", args[2], i)
+				}
+			}
+			secret := account.Wallet.Seed
+			if account.Mnemonic != "" {
+				secret = account.Mnemonic
+			}
+			entry, err := storeWallet(alias, secret, account.DerivationPath)
+			if err != nil {
+				log.Fatalf("Failed to store account in keystore: %v", err)
+			}
+			fmt.Printf("Account created successfully!\nAddress: %s\nWallet: %s\n", entry.Address, entry.Alias)
+			if entry.DerivationPath != "" {
+				fmt.Printf("Derivation path: %s\n", entry.DerivationPath)
+			}
+		}
+		if options.Mnemonic && options.RestoreMnemonic == "" {
+			fmt.Println("Back up the new mnemonic with 'wallet export', it restores every account derived from it")
+		}
+
+	case "fund-account", "fund-devnet-account":
 		if len(os.Args) < 3 {
-			fmt.Println("Usage: go run main.go fund-devnet-account <account-address>")
+			fmt.Printf("Usage: go run main.go %s <account-address>\n", os.Args[1])
 			return
 		}
 		address := types.Address(os.Args[2])
-		success, err := service.FundDevnetAccount(address)
+
+		// fund-devnet-account always uses the devnet faucet, fund-account the faucet of XRPL_FAUCET
+		if os.Args[1] == "fund-devnet-account" {
+			xrplService = xrplService.WithFaucet(service.NewHTTPFaucet(service.DevnetFaucetURL))
+		}
+
+		// Fund the account and wait until the payment is validated
+		funding, err := xrplService.FundAndWaitContext(ctx, address)
 		if err != nil {
 			log.Fatalf("Failed to fund account: %v", err)
 		}
-		if success {
-			fmt.Println("Devnet account funding request submitted successfully, please check balance later")
+		fmt.Printf("Account funded successfully!\nAmount: %s\nBalance: %s\nTransaction hash: %s\n",
+			funding.Amount, funding.Balance, funding.TxHash)
+
+	case "provision":
+		args := positionalArgs()
+		if len(args) < 3 {
+			fmt.Println("Usage: go run main.go provision <count> [alias-prefix] [--issuer] [--distributors] [--token <token-name>] [--limit <trust-limit>] [--vanity <address-prefix>] [--algorithm ed25519|secp256k1] [--mnemonic] [--manifest <file>]")
+			return
+		}
+		count, err := strconv.Atoi(args[2])
+		if err != nil || count < 1 {
+			log.Fatalf("Invalid account count %q", args[2])
+		}
+		prefix := "account"
+		if len(args) > 3 {
+			prefix = args[3]
+		}
+
+		options := &service.ProvisionOptions{
+			Count: count,
+			Account: service.CreateAccountOptions{
+				Algorithm: flagValue("--algorithm"),
+				Mnemonic:  hasFlag("--mnemonic"),
+			},
+			Vanity:       flagValue("--vanity"),
+			Issuer:       hasFlag("--issuer"),
+			Distributors: hasFlag("--distributors"),
+			TokenName:    flagValue("--token"),
+			TrustLimit:   flagValue("--limit"),
+		}
+		accounts, err := xrplService.GenerateAccounts(options)
+		if err != nil {
+			log.Fatalf("Failed to create accounts: %v", err)
+		}
+
+		// Save the wallets before funding them, so no funded account is lost if provisioning fails
+		aliases := make([]string, len(accounts))
+		pending := make([]service.ProvisionedAccount, len(accounts))
+		for i := range accounts {
+			aliases[i] = fmt.Sprintf("%s-%d", prefix, i)
+			pending[i].NewAccount = accounts[i]
+		}
+		manifestPath := flagValue("--manifest")
+		if manifestPath != "" {
+			if err := writeManifest(manifestPath, aliases, pending, false); err != nil {
+				log.Fatalf("Failed to write manifest: %v", err)
+			}
+		} else {
+			if err := storeWallets(aliases, accounts); err != nil {
+				log.Fatalf("Failed to store accounts in keystore: %v", err)
+			}
+		}
+
+		fmt.Printf("Funding %d accounts from the faucet and waiting for validation...\n", len(accounts))
+		results, err := xrplService.ProvisionAccountsContext(ctx, accounts, options)
+		if err != nil {
+			log.Fatalf("Failed to provision accounts: %v", err)
+		}
+		if manifestPath != "" {
+			if err := writeManifest(manifestPath, aliases, results, true); err != nil {
+				log.Fatalf("Failed to write manifest: %v", err)
+			}
+		}
+
+		failed := 0
+		for i, result := range results {
+			if result.Err != nil {
+				failed++
+				fmt.Printf("%d. %s %s (%s): failed: %v\n", i+1, aliases[i], result.Wallet.ClassicAddress, result.Role, result.Err)
+				continue
+			}
+			fmt.Printf("%d. %s %s (%s): %s\n", i+1, aliases[i], result.Wallet.ClassicAddress, result.Role, result.Balance)
+			for _, txResult := range result.Results {
+				fmt.Printf("   %s %s\n", txResult.Hash, txResult.EngineResult)
+			}
+		}
+		fmt.Printf("Provisioning finished: %d succeeded, %d failed\n", len(results)-failed, failed)
+		if manifestPath != "" {
+			fmt.Printf("Wallets written to %s, the file holds their secrets in plain text\n", manifestPath)
 		}
 
 	case "config-issuer":
 		if len(os.Args) < 3 {
-			fmt.Println("Usage: go run main.go config-issuer <account-secret>")
+			fmt.Println("Usage: go run main.go config-issuer <account-wallet>")
 			return
 		}
 
-		secret := os.Args[2]
+		walletName := os.Args[2]
 
-		// Restore wallet from secret
-		issuerWallet, err := wallet.FromSecret(secret)
+		// Restore wallet from the keystore
+		issuerSigner, err := restoreSigner(walletName)
 		if err != nil {
-			log.Fatalf("Failed to restore wallet from secret: %v", err)
+			log.Fatalf("Failed to restore wallet: %v", err)
 		}
 
 		// Configure issuer account
-		txHash, err := xrplService.ConfigureIssuerAccount(&issuerWallet, nil)
+		result, err := xrplService.ConfigureIssuerAccountContext(ctx, issuerSigner, nil)
 		if err != nil {
+			printTxResult(result)
 			log.Fatalf("Failed to configure issuer account: %v", err)
 		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
 
-		fmt.Printf("Issuer account configured successfully!\nAccount address: %s\nTransaction hash: %s\n",
-			issuerWallet.ClassicAddress, txHash)
+		fmt.Printf("Issuer account configured successfully!\nAccount address: %s\n",
+			issuerSigner.Address())
+		printTxResult(result)
 
 	case "config-distributor":
 		if len(os.Args) < 3 {
-			fmt.Println("Usage: go run main.go config-distributor <account-secret>")
+			fmt.Println("Usage: go run main.go config-distributor <account-wallet>")
 			return
 		}
 
-		secret := os.Args[2]
+		walletName := os.Args[2]
 
-		// Restore wallet from secret
-		distributorWallet, err := wallet.FromSecret(secret)
+		// Restore wallet from the keystore
+		distributorSigner, err := restoreSigner(walletName)
 		if err != nil {
-			log.Fatalf("Failed to restore wallet from secret: %v", err)
+			log.Fatalf("Failed to restore wallet: %v", err)
 		}
 
 		// Configure distributor account
-		txHash, err := xrplService.ConfigureDistributorAccount(&distributorWallet, nil)
+		result, err := xrplService.ConfigureDistributorAccountContext(ctx, distributorSigner, nil)
 		if err != nil {
+			printTxResult(result)
 			log.Fatalf("Failed to configure distributor account: %v", err)
 		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
 
-		fmt.Printf("Distributor account configured successfully!\nAccount address: %s\nTransaction hash: %s\n",
-			distributorWallet.ClassicAddress, txHash)
+		fmt.Printf("Distributor account configured successfully!\nAccount address: %s\n",
+			distributorSigner.Address())
+		printTxResult(result)
 
 	case "create-trustline":
 		if len(os.Args) < 6 {
-			fmt.Println("Usage: go run main.go create-trustline <account-secret> <issuer-address> <token-name> <trust-limit>")
+			fmt.Println("Usage: go run main.go create-trustline <account-wallet> <issuer-address> <token-name> <trust-limit> [--use-ticket]")
 			return
 		}
 
-		secret := os.Args[2]
+		walletName := os.Args[2]
 		issuerAddress := types.Address(os.Args[3])
 		tokenName := os.Args[4]
 		amount := os.Args[5]
 
-		// Restore wallet from secret
-		receiverWallet, err := wallet.FromSecret(secret)
+		// Restore wallet from the keystore
+		receiverSigner, err := restoreSigner(walletName)
 		if err != nil {
-			log.Fatalf("Failed to restore wallet from secret: %v", err)
+			log.Fatalf("Failed to restore wallet: %v", err)
 		}
 
 		// Create trust line options
@@ -118,20 +334,168 @@ func main() {
 			IssuerAddress: issuerAddress,
 			TokenName:     tokenName,
 			Amount:        amount,
+			UseTicket:     hasFlag("--use-ticket"),
 		}
 
 		// Create trust line
-		txHash, err := xrplService.CreateTrustLine(&receiverWallet, trustLineOptions)
+		result, err := xrplService.CreateTrustLineContext(ctx, receiverSigner, trustLineOptions)
 		if err != nil {
+			printTxResult(result)
 			log.Fatalf("Failed to create trust line: %v", err)
 		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
+
+		fmt.Printf("Trust line created successfully!\nReceiver address: %s\nIssuer address: %s\nToken name: %s\nTrust limit: %s\n",
+			receiverSigner.Address(), issuerAddress, tokenName, amount)
+		printTxResult(result)
+
+	case "freeze-trustline", "unfreeze-trustline":
+		if len(os.Args) < 5 {
+			fmt.Printf("Usage: go run main.go %s <account-wallet> <trustline-address> <token-name> [--deep]\n", os.Args[1])
+			return
+		}
+
+		walletName := os.Args[2]
+		trustlineAddress := types.Address(os.Args[3])
+		tokenName := os.Args[4]
+
+		// Restore wallet from the keystore
+		accountSigner, err := restoreSigner(walletName)
+		if err != nil {
+			log.Fatalf("Failed to restore wallet: %v", err)
+		}
+
+		// Freeze or unfreeze the trust line, or with --deep set or clear the deep freeze of a frozen line
+		var result *service.TxResult
+		deep := hasFlag("--deep")
+		switch {
+		case os.Args[1] == "freeze-trustline" && deep:
+			result, err = xrplService.DeepFreezeTrustLineContext(ctx, accountSigner, trustlineAddress, tokenName)
+		case os.Args[1] == "freeze-trustline":
+			result, err = xrplService.FreezeTrustLineContext(ctx, accountSigner, trustlineAddress, tokenName)
+		case deep:
+			result, err = xrplService.ClearDeepFreezeTrustLineContext(ctx, accountSigner, trustlineAddress, tokenName)
+		default:
+			result, err = xrplService.UnfreezeTrustLineContext(ctx, accountSigner, trustlineAddress, tokenName)
+		}
+		if err != nil {
+			printTxResult(result)
+			log.Fatalf("Failed to update trust line: %v", err)
+		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
+
+		fmt.Printf("Trust line updated successfully!\nAccount address: %s\nCounterparty address: %s\nToken name: %s\n",
+			accountSigner.Address(), trustlineAddress, tokenName)
+		if deep {
+			fmt.Printf("Deep frozen: %t\n", os.Args[1] == "freeze-trustline")
+		} else {
+			fmt.Printf("Frozen: %t\n", os.Args[1] == "freeze-trustline")
+		}
+		printTxResult(result)
+
+	case "clawback":
+		args := positionalArgs()
+		if len(args) < 5 {
+			fmt.Println("Usage: go run main.go clawback <issuer-wallet> <holder-address> <token-name> [amount]")
+			return
+		}
+
+		options := &service.ClawbackOptions{
+			HolderAddress: types.Address(args[3]),
+			TokenName:     args[4],
+		}
+		// Claw back the whole balance unless an amount is given
+		if len(args) > 5 {
+			options.Amount = args[5]
+		}
+
+		// Restore wallet from the keystore
+		issuerSigner, err := restoreSigner(args[2])
+		if err != nil {
+			log.Fatalf("Failed to restore wallet: %v", err)
+		}
+
+		result, err := xrplService.ClawbackContext(ctx, issuerSigner, options)
+		if err != nil {
+			if result != nil {
+				printTxResult(result.TxResult)
+			}
+			log.Fatalf("Failed to claw back tokens: %v", err)
+		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result.TxResult)
+			return
+		}
+
+		fmt.Printf("Tokens clawed back successfully!\nIssuer address: %s\nHolder address: %s\nToken name: %s\n",
+			issuerSigner.Address(), options.HolderAddress, options.TokenName)
+		fmt.Printf("Holder balance: %s before, %s after\n", result.BalanceBefore, result.BalanceAfter)
+		printTxResult(result.TxResult)
+
+	case "global-freeze", "global-unfreeze", "set-no-freeze":
+		if len(os.Args) < 3 {
+			fmt.Printf("Usage: go run main.go %s <issuer-wallet>\n", os.Args[1])
+			return
+		}
+
+		// Restore wallet from the keystore
+		issuerSigner, err := restoreSigner(os.Args[2])
+		if err != nil {
+			log.Fatalf("Failed to restore wallet: %v", err)
+		}
+
+		var result *service.TxResult
+		switch os.Args[1] {
+		case "global-freeze":
+			result, err = xrplService.SetGlobalFreezeContext(ctx, issuerSigner, true)
+		case "global-unfreeze":
+			result, err = xrplService.SetGlobalFreezeContext(ctx, issuerSigner, false)
+		case "set-no-freeze":
+			// NoFreeze can never be cleared, confirm before submitting it
+			if !hasFlag("--yes") && !hasFlag("--dry-run") && !prepareOnly {
+				answer, err := readLine(fmt.Sprintf("Set NoFreeze on %s? The account permanently gives up freezing trust lines and lifting a global freeze. Type the account address to confirm: ", issuerSigner.Address()))
+				if err != nil || strings.TrimSpace(answer) != issuerSigner.Address().String() {
+					fmt.Println("NoFreeze not set")
+					return
+				}
+			}
+			result, err = xrplService.SetNoFreezeContext(ctx, issuerSigner)
+		}
+		if err != nil {
+			printTxResult(result)
+			log.Fatalf("Failed to update freeze settings: %v", err)
+		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
+
+		fmt.Printf("Freeze settings updated successfully!\nIssuer address: %s\n", issuerSigner.Address())
+		printTxResult(result)
+
+	case "get-freeze-state":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: go run main.go get-freeze-state <issuer-address>")
+			return
+		}
 
-		fmt.Printf("Trust line created successfully!\nReceiver address: %s\nIssuer address: %s\nToken name: %s\nTrust limit: %s\nTransaction hash: %s\n",
-			receiverWallet.ClassicAddress, issuerAddress, tokenName, amount, txHash)
+		state, err := xrplService.GetFreezeStateContext(ctx, types.Address(os.Args[2]))
+		if err != nil {
+			log.Fatalf("Failed to query freeze state: %v", err)
+		}
+		fmt.Printf("Issuer address: %s\n", state.Account)
+		fmt.Printf("Global freeze: %t\n", state.GlobalFreeze)
+		fmt.Printf("NoFreeze: %t\n", state.NoFreeze)
 
 	case "transfer-token":
 		if len(os.Args) < 7 {
-			fmt.Println("Usage: go run main.go transfer-token <sender-secret> <receiver-address> <issuer-address> <token-name> <amount>")
+			fmt.Println("Usage: go run main.go transfer-token <sender-wallet> <receiver-address> <issuer-address> <token-name> <amount> [--use-ticket]")
 			return
 		}
 
@@ -141,10 +505,10 @@ func main() {
 		tokenName := os.Args[5]
 		amount := os.Args[6]
 
-		// Restore sender wallet from secret
-		senderWallet, err := wallet.FromSecret(senderKey)
+		// Restore sender wallet from the keystore
+		senderSigner, err := restoreSigner(senderKey)
 		if err != nil {
-			log.Fatalf("Failed to restore wallet from secret: %v", err)
+			log.Fatalf("Failed to restore wallet: %v", err)
 		}
 
 		// Create transfer token options
@@ -153,84 +517,545 @@ func main() {
 			IssuerAddress:   issuerAddress,
 			TokenName:       tokenName,
 			Amount:          amount,
+			UseTicket:       hasFlag("--use-ticket"),
 		}
 
 		// Transfer token
-		txHash, err := xrplService.TransferToken(&senderWallet, transferOptions)
+		result, err := xrplService.TransferTokenContext(ctx, senderSigner, transferOptions)
 		if err != nil {
+			printTxResult(result)
 			log.Fatalf("Failed to transfer token: %v", err)
 		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
 
-		fmt.Printf("Token transferred successfully!\nSender: %s\nReceiver: %s\nToken name: %s\nAmount: %s\nTransaction hash: %s\n",
-			senderWallet.ClassicAddress, receiverAddress, tokenName, amount, txHash)
+		fmt.Printf("Token transferred successfully!\nSender: %s\nReceiver: %s\nToken name: %s\nAmount: %s\n",
+			senderSigner.Address(), receiverAddress, tokenName, amount)
+		printTxResult(result)
 
-	case "get-balance":
-		if len(os.Args) < 3 {
-			fmt.Println("Usage: go run main.go get-balance <account-address>")
+	case "transfer-token-batch":
+		if len(os.Args) < 6 {
+			fmt.Println("Usage: go run main.go transfer-token-batch <sender-wallet> <issuer-address> <token-name> <transfers-csv> [--use-tickets]")
 			return
 		}
-		address := types.Address(os.Args[2])
-		balance, err := xrplService.GetBalance(address)
+
+		senderKey := os.Args[2]
+		issuerAddress := types.Address(os.Args[3])
+		tokenName := os.Args[4]
+
+		// Restore sender wallet from the keystore
+		senderSigner, err := restoreSigner(senderKey)
 		if err != nil {
-			log.Fatalf("Failed to get account balance: %v", err)
+			log.Fatalf("Failed to restore wallet: %v", err)
 		}
-		fmt.Printf("XRP balance for account %s: %s\n", address, balance)
 
-	case "get-tokens":
+		// Read transfers, one "receiver-address,amount" line per payment
+		file, err := os.Open(os.Args[5])
+		if err != nil {
+			log.Fatalf("Failed to open transfers file: %v", err)
+		}
+		records, err := csv.NewReader(file).ReadAll()
+		file.Close()
+		if err != nil {
+			log.Fatalf("Failed to read transfers file: %v", err)
+		}
+
+		var transfers []*service.TransferTokenOptions
+		for _, record := range records {
+			if len(record) < 2 {
+				log.Fatalf("Invalid transfer line %q, expected receiver-address,amount", strings.Join(record, ","))
+			}
+			transfers = append(transfers, &service.TransferTokenOptions{
+				ReceiverAddress: types.Address(strings.TrimSpace(record[0])),
+				IssuerAddress:   issuerAddress,
+				TokenName:       tokenName,
+				Amount:          strings.TrimSpace(record[1]),
+				UseTicket:       hasFlag("--use-tickets"),
+			})
+		}
+
+		// Transfer tokens, pipelining payments from the sender
+		results := xrplService.TransferTokensContext(ctx, senderSigner, transfers)
+
+		failed := 0
+		for i, transfer := range results {
+			if transfer.Err != nil {
+				failed++
+				fmt.Printf("%d. %s %s: failed: %v\n", i+1, transfer.Options.ReceiverAddress, transfer.Options.Amount, transfer.Err)
+				continue
+			}
+			fmt.Printf("%d. %s %s: %s (sequence %d, ledger %d)\n", i+1, transfer.Options.ReceiverAddress, transfer.Options.Amount,
+				transfer.Result.Hash, transfer.Result.Sequence, transfer.Result.LedgerIndex)
+		}
+		fmt.Printf("Batch transfer finished: %d succeeded, %d failed\n", len(results)-failed, failed)
+
+	case "freeze-trustline-batch", "unfreeze-trustline-batch":
+		if len(os.Args) < 5 {
+			fmt.Printf("Usage: go run main.go %s <account-wallet> <token-name> <addresses-file>\n", os.Args[1])
+			return
+		}
+
+		tokenName := os.Args[3]
+
+		// Restore wallet from the keystore
+		accountSigner, err := restoreSigner(os.Args[2])
+		if err != nil {
+			log.Fatalf("Failed to restore wallet: %v", err)
+		}
+
+		addresses, err := readAddresses(os.Args[4])
+		if err != nil {
+			log.Fatalf("Failed to read addresses file: %v", err)
+		}
+
+		// Update every trust line, pipelining the transactions
+		freeze := os.Args[1] == "freeze-trustline-batch"
+		printTrustLineResults(xrplService.FreezeTrustLinesContext(ctx, accountSigner, addresses, tokenName, freeze))
+
+	case "authorize-trustline":
+		if len(os.Args) < 5 {
+			fmt.Println("Usage: go run main.go authorize-trustline <issuer-wallet> <holder-address> <token-name>")
+			return
+		}
+
+		holderAddress := types.Address(os.Args[3])
+		tokenName := os.Args[4]
+
+		// Restore wallet from the keystore
+		issuerSigner, err := restoreSigner(os.Args[2])
+		if err != nil {
+			log.Fatalf("Failed to restore wallet: %v", err)
+		}
+
+		result, err := xrplService.AuthorizeTrustLineContext(ctx, issuerSigner, holderAddress, tokenName)
+		if err != nil {
+			printTxResult(result)
+			log.Fatalf("Failed to authorize trust line: %v", err)
+		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
+
+		fmt.Printf("Trust line authorized successfully!\nIssuer address: %s\nHolder address: %s\nToken name: %s\n",
+			issuerSigner.Address(), holderAddress, tokenName)
+		printTxResult(result)
+
+	case "authorize-trustline-batch":
+		args := positionalArgs()
+		if len(args) < 4 {
+			fmt.Println("Usage: go run main.go authorize-trustline-batch <issuer-wallet> <token-name> [addresses-file]")
+			return
+		}
+
+		tokenName := args[3]
+
+		// Restore wallet from the keystore
+		issuerSigner, err := restoreSigner(args[2])
+		if err != nil {
+			log.Fatalf("Failed to restore wallet: %v", err)
+		}
+
+		// Authorize the listed holders, or every holder waiting for authorization
+		var addresses []types.Address
+		if len(args) > 4 {
+			addresses, err = readAddresses(args[4])
+			if err != nil {
+				log.Fatalf("Failed to read addresses file: %v", err)
+			}
+		} else {
+			pending, err := xrplService.PendingAuthorizationsContext(ctx, issuerSigner.Address(), tokenName)
+			if err != nil {
+				log.Fatalf("Failed to query pending authorizations: %v", err)
+			}
+			for _, line := range pending {
+				addresses = append(addresses, types.Address(line.Account))
+			}
+			if len(addresses) == 0 {
+				fmt.Printf("No %s trust lines waiting for authorization\n", tokenName)
+				return
+			}
+		}
+
+		printTrustLineResults(xrplService.AuthorizeTrustLinesContext(ctx, issuerSigner, addresses, tokenName))
+
+	case "get-pending-authorizations":
+		args := positionalArgs()
+		if len(args) < 3 {
+			fmt.Println("Usage: go run main.go get-pending-authorizations <issuer-address> [token-name]")
+			return
+		}
+		tokenName := ""
+		if len(args) > 3 {
+			tokenName = args[3]
+		}
+
+		pending, err := xrplService.PendingAuthorizationsContext(ctx, types.Address(args[2]), tokenName)
+		if err != nil {
+			log.Fatalf("Failed to query pending authorizations: %v", err)
+		}
+		fmt.Printf("%d trust lines waiting for authorization:\n", len(pending))
+		for i, line := range pending {
+			fmt.Printf("%d. %s %s (holder limit %s, balance %s)\n", i+1, line.Account, line.Currency, line.LimitPeer, line.Balance)
+		}
+
+	case "create-tickets":
+		if len(os.Args) < 4 {
+			fmt.Println("Usage: go run main.go create-tickets <account-wallet> <count>")
+			return
+		}
+
+		walletName := os.Args[2]
+		count, err := strconv.ParseUint(os.Args[3], 10, 32)
+		if err != nil {
+			log.Fatalf("Invalid ticket count: %v", err)
+		}
+
+		// Restore wallet from the keystore
+		accountSigner, err := restoreSigner(walletName)
+		if err != nil {
+			log.Fatalf("Failed to restore wallet: %v", err)
+		}
+
+		// Create tickets
+		result, tickets, err := xrplService.CreateTicketsContext(ctx, accountSigner, uint32(count))
+		if err != nil {
+			printTxResult(result)
+			log.Fatalf("Failed to create tickets: %v", err)
+		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
+
+		fmt.Printf("Tickets created successfully!\nAccount address: %s\nTickets: %v\n",
+			accountSigner.Address(), tickets)
+		printTxResult(result)
+
+	case "get-tickets":
 		if len(os.Args) < 3 {
-			fmt.Println("Usage: go run main.go get-tokens <account-address>")
+			fmt.Println("Usage: go run main.go get-tickets <account-address>")
 			return
 		}
 		address := types.Address(os.Args[2])
-		tokens, err := xrplService.GetTokenBalances(address)
+		tickets, err := xrplService.TicketsContext(ctx, address)
 		if err != nil {
-			log.Fatalf("Failed to get account tokens: %v", err)
+			log.Fatalf("Failed to get account tickets: %v", err)
 		}
 
-		if len(tokens) == 0 {
-			fmt.Printf("Account %s does not hold any tokens\n", address)
+		if len(tickets) == 0 {
+			fmt.Printf("Account %s has no tickets\n", address)
 			return
 		}
+		fmt.Printf("Tickets available to account %s (%d): %v\n", address, len(tickets), tickets)
 
-		fmt.Printf("Token list held by account %s:\n", address)
-		for i, token := range tokens {
-			fmt.Printf("%d. Token: %s\n   Issuer: %s\n   Balance: %s\n   Limit: %s\n",
-				i+1, token.TokenName, token.Issuer, token.Balance, token.LimitAmount)
+	case "set-signer-list":
+		args := positionalArgs()
+		if len(args) < 4 {
+			fmt.Println("Usage: go run main.go set-signer-list <account-wallet> <quorum> [<signer-address>:<weight>...]")
+			return
 		}
 
-	case "get-trustlines":
+		walletName := args[2]
+		quorum, err := strconv.ParseUint(args[3], 10, 32)
+		if err != nil {
+			log.Fatalf("Invalid quorum: %v", err)
+		}
+
+		// Parse signers given as address:weight, a quorum of 0 without signers removes the list
+		signerList := &service.SignerList{Quorum: uint32(quorum)}
+		for _, arg := range args[4:] {
+			address, weightValue, found := strings.Cut(arg, ":")
+			weight, err := strconv.ParseUint(weightValue, 10, 16)
+			if !found || err != nil {
+				log.Fatalf("Invalid signer %q, expected signer-address:weight", arg)
+			}
+			signerList.Signers = append(signerList.Signers, service.SignerEntry{
+				Account: types.Address(address),
+				Weight:  uint16(weight),
+			})
+		}
+
+		// Restore wallet from the keystore
+		accountSigner, err := restoreSigner(walletName)
+		if err != nil {
+			log.Fatalf("Failed to restore wallet: %v", err)
+		}
+
+		// Install the signer list
+		result, err := xrplService.SetSignerListContext(ctx, accountSigner, signerList)
+		if err != nil {
+			printTxResult(result)
+			log.Fatalf("Failed to set signer list: %v", err)
+		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
+
+		fmt.Printf("Signer list set successfully!\nAccount address: %s\nQuorum: %d\nSigners: %d\n",
+			accountSigner.Address(), signerList.Quorum, len(signerList.Signers))
+		printTxResult(result)
+
+	case "set-regular-key", "remove-regular-key":
+		args := positionalArgs()
+		if len(args) < 3 || (os.Args[1] == "set-regular-key" && len(args) < 4) {
+			fmt.Printf("Usage: go run main.go %s <account-wallet> [regular-key-address-or-wallet] [--account <account-address>]\n", os.Args[1])
+			return
+		}
+
+		walletName := os.Args[2]
+
+		// Restore wallet from the keystore, either the master key or the current regular key
+		accountSigner, err := restoreSigner(walletName)
+		if err != nil {
+			log.Fatalf("Failed to restore wallet: %v", err)
+		}
+
+		// The regular key is given by its address or the alias of a keystore wallet holding it
+		var regularKey types.Address
+		if os.Args[1] == "set-regular-key" {
+			regularKey, err = resolveAddress(args[3])
+			if err != nil {
+				log.Fatalf("Invalid regular key: %v", err)
+			}
+		}
+
+		// Set, rotate or remove the regular key
+		result, err := xrplService.SetRegularKeyContext(ctx, accountSigner, regularKey)
+		if err != nil {
+			printTxResult(result)
+			log.Fatalf("Failed to set regular key: %v", err)
+		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
+			return
+		}
+
+		if regularKey == "" {
+			fmt.Printf("Regular key removed successfully!\nAccount address: %s\n", accountSigner.Address())
+		} else {
+			fmt.Printf("Regular key set successfully!\nAccount address: %s\nRegular key address: %s\n", accountSigner.Address(), regularKey)
+		}
+		printTxResult(result)
+
+	case "disable-master-key", "enable-master-key":
 		if len(os.Args) < 3 {
-			fmt.Println("Usage: go run main.go get-trustlines <account-address>")
+			fmt.Printf("Usage: go run main.go %s <account-wallet> [--account <account-address>]\n", os.Args[1])
 			return
 		}
-		address := types.Address(os.Args[2])
-		trustlines, err := xrplService.GetAllTrustLines(address)
+
+		walletName := os.Args[2]
+
+		// Restore wallet from the keystore, either the master key or the regular key
+		accountSigner, err := restoreSigner(walletName)
 		if err != nil {
-			log.Fatalf("Failed to get account trust lines: %v", err)
+			log.Fatalf("Failed to restore wallet: %v", err)
 		}
 
-		if len(trustlines.Lines) == 0 {
-			fmt.Printf("Account %s has no trust lines\n", address)
+		// Disable or re-enable the master key
+		var result *service.TxResult
+		if os.Args[1] == "disable-master-key" {
+			result, err = xrplService.DisableMasterKeyContext(ctx, accountSigner)
+		} else {
+			result, err = xrplService.EnableMasterKeyContext(ctx, accountSigner)
+		}
+		if err != nil {
+			printTxResult(result)
+			log.Fatalf("Failed to update master key: %v", err)
+		}
+		if result.DryRun || result.Prepared {
+			printTxResult(result)
 			return
 		}
 
-		fmt.Printf("Trust line details for account %s:\n", address)
-		fmt.Printf("Validation status: %t\n\n", trustlines.Validated)
-		for i, line := range trustlines.Lines {
-			fmt.Printf("%d. Trust line details:\n", i+1)
-			fmt.Printf("   Counterparty address: %s\n", line.Account)
-			fmt.Printf("   Currency code: %s\n", line.Currency)
-			fmt.Printf("   Balance: %s\n", line.Balance)
-			fmt.Printf("   Account limit: %s\n", line.Limit)
-			fmt.Printf("   Counterparty limit: %s\n", line.LimitPeer)
-			fmt.Printf("   Quality (receiving): %d\n", line.QualityIn)
-			fmt.Printf("   Quality (sending): %d\n", line.QualityOut)
-			fmt.Printf("   NoRipple flag: %t\n", line.NoRipple)
-			fmt.Printf("   Counterparty NoRipple: %t\n", line.NoRipplePeer)
-			fmt.Printf("   Authorized: %t\n", line.Authorized)
-			fmt.Printf("   Counterparty authorized: %t\n", line.PeerAuthorized)
-			fmt.Printf("   Frozen: %t\n", line.Freeze)
-			fmt.Printf("   Counterparty frozen: %t\n\n", line.FreezePeer)
+		fmt.Printf("Master key updated successfully!\nAccount address: %s\nMaster key disabled: %t\n",
+			accountSigner.Address(), os.Args[1] == "disable-master-key")
+		printTxResult(result)
+
+	case "get-account-keys":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: go run main.go get-account-keys <account-address>")
+			return
+		}
+		address := types.Address(os.Args[2])
+		keys, err := xrplService.AccountKeysContext(ctx, address)
+		if err != nil {
+			log.Fatalf("Failed to get account keys: %v", err)
+		}
+
+		fmt.Printf("Signing keys of account %s:\n", address)
+		fmt.Printf("   Master key disabled: %t\n", keys.MasterDisabled)
+		if keys.RegularKey != "" {
+			fmt.Printf("   Regular key: %s\n", keys.RegularKey)
+		} else {
+			fmt.Println("   Regular key: none")
+		}
+		if keys.SignerList != nil {
+			fmt.Printf("   Signer list: %d signers, quorum %d\n", len(keys.SignerList.Signers), keys.SignerList.Quorum)
+		} else {
+			fmt.Println("   Signer list: none")
+		}
+
+	case "get-signer-list":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: go run main.go get-signer-list <account-address>")
+			return
+		}
+		address := types.Address(os.Args[2])
+		signerList, err := xrplService.SignerListContext(ctx, address)
+		if err != nil {
+			log.Fatalf("Failed to get signer list: %v", err)
+		}
+
+		if signerList == nil {
+			fmt.Printf("Account %s has no signer list\n", address)
+			return
+		}
+		fmt.Printf("Signer list of account %s (quorum %d):\n", address, signerList.Quorum)
+		for i, signer := range signerList.Signers {
+			fmt.Printf("%d. %s (weight %d)\n", i+1, signer.Account, signer.Weight)
+		}
+
+	case "submit":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: go run main.go submit <signed-file-or-blob>")
+			return
+		}
+
+		txBlob, err := readTransactionBlob(os.Args[2])
+		if err != nil {
+			log.Fatalf("Failed to read signed transaction: %v", err)
+		}
+
+		// Submit the signed transaction and wait for validation
+		result, err := xrplService.SubmitSignedTransactionContext(ctx, txBlob)
+		if err != nil {
+			printTxResult(result)
+			log.Fatalf("Failed to submit transaction: %v", err)
+		}
+		if result.DryRun {
+			printTxResult(result)
+			return
+		}
+
+		fmt.Println("Transaction submitted successfully!")
+		printTxResult(result)
+
+	case "get-balance":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: go run main.go get-balance <account-address>")
+			return
+		}
+		address := types.Address(os.Args[2])
+		balance, err := xrplService.GetAccountBalanceContext(ctx, address)
+		if err != nil {
+			log.Fatalf("Failed to get account balance: %v", err)
+		}
+		fmt.Printf("XRP balance for account %s: %s\n", address, balance.Balance)
+		fmt.Printf("Served by node: %s\n", balance.Node)
+
+	case "get-tokens":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: go run main.go get-tokens <account-address>")
+			return
+		}
+		address := types.Address(os.Args[2])
+		tokens, err := xrplService.GetAccountTokensContext(ctx, address)
+		if err != nil {
+			log.Fatalf("Failed to get account tokens: %v", err)
+		}
+
+		if len(tokens.Tokens) == 0 {
+			fmt.Printf("Account %s does not hold any tokens\n", address)
+			return
+		}
+
+		fmt.Printf("Token list held by account %s:\n", address)
+		fmt.Printf("Served by node: %s\n\n", tokens.Node)
+		for i, token := range tokens.Tokens {
+			fmt.Printf("%d. Token: %s\n   Issuer: %s\n   Balance: %s\n   Limit: %s\n",
+				i+1, token.TokenName, token.Issuer, token.Balance, token.LimitAmount)
+		}
+
+	case "get-trustlines":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: go run main.go get-trustlines <account-address>")
+			return
+		}
+		address := types.Address(os.Args[2])
+		trustlines, err := xrplService.GetAllTrustLinesContext(ctx, address)
+		if err != nil {
+			log.Fatalf("Failed to get account trust lines: %v", err)
+		}
+
+		if len(trustlines.Lines) == 0 {
+			fmt.Printf("Account %s has no trust lines\n", address)
+			return
+		}
+
+		fmt.Printf("Trust line details for account %s:\n", address)
+		fmt.Printf("Validation status: %t\n", trustlines.Validated)
+		fmt.Printf("Served by node: %s\n\n", trustlines.Node)
+		for i, line := range trustlines.Lines {
+			fmt.Printf("%d. Trust line details:\n", i+1)
+			fmt.Printf("   Counterparty address: %s\n", line.Account)
+			fmt.Printf("   Currency code: %s\n", line.Currency)
+			fmt.Printf("   Balance: %s\n", line.Balance)
+			fmt.Printf("   Account limit: %s\n", line.Limit)
+			fmt.Printf("   Counterparty limit: %s\n", line.LimitPeer)
+			fmt.Printf("   Quality (receiving): %d\n", line.QualityIn)
+			fmt.Printf("   Quality (sending): %d\n", line.QualityOut)
+			fmt.Printf("   NoRipple flag: %t\n", line.NoRipple)
+			fmt.Printf("   Counterparty NoRipple: %t\n", line.NoRipplePeer)
+			fmt.Printf("   Authorized: %t\n", line.Authorized)
+			fmt.Printf("   Counterparty authorized: %t\n", line.PeerAuthorized)
+			fmt.Printf("   Frozen: %t\n", line.Freeze)
+			fmt.Printf("   Counterparty frozen: %t\n", line.FreezePeer)
+			fmt.Printf("   Deep frozen: %t\n", line.DeepFreeze)
+			fmt.Printf("   Counterparty deep frozen: %t\n\n", line.DeepFreezePeer)
+		}
+
+	case "nodes":
+		// Check every configured node now instead of waiting for the background health check
+		fmt.Println("XRPL node health:")
+		for i, node := range xrplService.NodeStatus(true) {
+			fmt.Printf("%d. %s\n", i+1, node.URL)
+			fmt.Printf("   Healthy: %t\n", node.Healthy)
+			if node.Error != "" {
+				fmt.Printf("   Error: %s\n", node.Error)
+				continue
+			}
+			fmt.Printf("   Server state: %s\n", node.ServerState)
+			fmt.Printf("   Latency: %d ms\n", node.LatencyMS)
+			fmt.Printf("   Validated ledger: %d (age %d s)\n", node.ValidatedLedger, node.LedgerAge)
+		}
+
+	case "network":
+		fmt.Printf("Network: %s\n", network)
+		fmt.Printf("Mainnet: %t\n", network.Mainnet)
+		fmt.Printf("Nodes: %s\n", strings.Join(cfg.NodeURLs, ", "))
+		if cfg.Faucet != "" {
+			fmt.Printf("Faucet: %s\n", cfg.Faucet)
+		}
+		if network.ExplorerURL != "" {
+			fmt.Printf("Explorer: %s\n", network.ExplorerURL)
+		}
+
+		// Compare the active node with the profile
+		checks, err := xrplService.CheckNetworkContext(ctx)
+		if err != nil {
+			log.Fatalf("Failed to query the active node: %v", err)
+		}
+		fmt.Printf("Active node: %s\n", xrplService.ActiveNode())
+		for _, check := range checks {
+			status := "PASS"
+			if !check.Passed {
+				status = "FAIL"
+			}
+			fmt.Printf("   [%s] %s: %s\n", status, check.Name, check.Detail)
 		}
 
 	default:
@@ -238,15 +1063,729 @@ func main() {
 	}
 }
 
+// Write commands that can be prepared for offline signing
+var preparableCommands = map[string]bool{
+	"config-issuer":       true,
+	"config-distributor":  true,
+	"create-trustline":    true,
+	"transfer-token":      true,
+	"create-tickets":      true,
+	"freeze-trustline":    true,
+	"unfreeze-trustline":  true,
+	"clawback":            true,
+	"authorize-trustline": true,
+	"global-freeze":       true,
+	"global-unfreeze":     true,
+	"set-no-freeze":       true,
+	"set-signer-list":     true,
+	"set-regular-key":     true,
+	"remove-regular-key":  true,
+	"disable-master-key":  true,
+	"enable-master-key":   true,
+}
+
+// Get the value following an optional flag, empty if the flag was not passed
+func flagValue(name string) string {
+	for i, arg := range os.Args[2:] {
+		if arg == name && i+3 < len(os.Args) {
+			return os.Args[i+3]
+		}
+	}
+	return ""
+}
+
+// Optional flags followed by a value
+var valueFlags = map[string]bool{
+	"--signers":   true,
+	"--account":   true,
+	"--algorithm": true,
+	"--path":      true,
+	"--count":     true,
+	"--token":     true,
+	"--limit":     true,
+	"--vanity":    true,
+	"--manifest":  true,
+}
+
+// Get the command line arguments without optional flags and their values
+func positionalArgs() []string {
+	var args []string
+	for i := 0; i < len(os.Args); i++ {
+		if valueFlags[os.Args[i]] {
+			i++
+			continue
+		}
+		if !strings.HasPrefix(os.Args[i], "--") {
+			args = append(args, os.Args[i])
+		}
+	}
+	return args
+}
+
+// Whether the command prepares an unsigned transaction instead of submitting it
+var prepareOnly bool
+
+// Network profile of the configured nodes
+var network config.Network
+
+// Restore the signer of a wallet stored in the keystore under an alias, of a key of the remote signing
+// service named remote:<key>, or of a secret given directly. When preparing, the argument may also be
+// the account address and the signer cannot sign. With --account the wallet may hold the account's regular key.
+func restoreSigner(walletName string) (service.Signer, error) {
+	if prepareOnly {
+		address, err := resolveAddress(walletName)
+		if err != nil {
+			return nil, err
+		}
+		return service.NewWalletSigner(&wallet.Wallet{ClassicAddress: address}), nil
+	}
+
+	// The signing service knows which account each of its keys signs for
+	if key, ok := strings.CutPrefix(walletName, "remote:"); ok {
+		return service.NewRemoteSigner(context.Background(), signerURL, signerToken, key)
+	}
+
+	// Ask for the passphrase and check it now rather than once the transaction is autofilled.
+	// The keystore signer decrypts the wallet again for each signature, with the passphrase entered here.
+	account := types.Address(flagValue("--account"))
+	_, found, err := unlockWallet(walletName)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		store, err := keystore.Open(keystoreDir)
+		if err != nil {
+			return nil, err
+		}
+		return store.Signer(walletName, account, func() (string, error) {
+			return readPassphrase("", false)
+		})
+	}
+
+	if _, err := wallet.FromSecret(walletName); err != nil {
+		return nil, fmt.Errorf("%w: %s", keystore.ErrWalletNotFound, walletName)
+	}
+	log.Println("Warning: secrets passed on the command line leak into shell history and process listings, store the wallet with 'wallet import' and use its alias instead")
+	signerWallet, err := wallet.FromSeed(walletName, account.String())
+	if err != nil {
+		return nil, err
+	}
+	return service.NewWalletSigner(&signerWallet), nil
+}
+
+// Directory of the encrypted wallet keystore
+var keystoreDir string
+
+// Passphrase entered once per run and reused for every wallet unlocked or stored
+var keystorePassphrase string
+
+// Remote signing service and the token authorizing requests to it
+var signerURL, signerToken string
+
+// Unlock a keystore wallet, asking for its passphrase; found is false if no wallet is stored under the name
+func unlockWallet(walletName string) (unlocked *wallet.Wallet, found bool, err error) {
+	if !keystore.ValidAlias(walletName) {
+		return nil, false, nil
+	}
+	store, err := keystore.Open(keystoreDir)
+	if err != nil {
+		return nil, false, err
+	}
+	if !store.Has(walletName) {
+		return nil, false, nil
+	}
+
+	passphrase, err := readPassphrase(fmt.Sprintf("Passphrase for wallet %s: ", walletName), false)
+	if err != nil {
+		return nil, true, err
+	}
+	unlocked, err = store.Unlock(walletName, passphrase)
+	return unlocked, true, err
+}
+
+// Get the address of a keystore wallet without unlocking it, or take the argument as the address itself
+func resolveAddress(addressOrAlias string) (types.Address, error) {
+	if addresscodec.IsValidClassicAddress(addressOrAlias) {
+		return types.Address(addressOrAlias), nil
+	}
+	if keystore.ValidAlias(addressOrAlias) {
+		store, err := keystore.Open(keystoreDir)
+		if err != nil {
+			return "", err
+		}
+		if entry, err := store.Lookup(addressOrAlias); err == nil {
+			return entry.Address, nil
+		}
+	}
+	return "", fmt.Errorf("%q is neither an address nor a keystore wallet", addressOrAlias)
+}
+
+// Encrypt a secret into the keystore under alias, asking for a new passphrase. A secret with a
+// derivation path is a mnemonic.
+func storeWallet(alias, secret, path string) (*keystore.Entry, error) {
+	store, err := keystore.Open(keystoreDir)
+	if err != nil {
+		return nil, err
+	}
+	if store.Has(alias) {
+		return nil, fmt.Errorf("%w: %s", keystore.ErrWalletExists, alias)
+	}
+	passphrase, err := readPassphrase(fmt.Sprintf("New passphrase for wallet %s: ", alias), true)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		return store.ImportMnemonic(alias, secret, path, passphrase)
+	}
+	return store.Import(alias, secret, passphrase)
+}
+
+// Encrypt new accounts into the keystore under aliases, checking every alias is free before storing any
+func storeWallets(aliases []string, accounts []service.NewAccount) error {
+	store, err := keystore.Open(keystoreDir)
+	if err != nil {
+		return err
+	}
+	for _, alias := range aliases {
+		if store.Has(alias) {
+			return fmt.Errorf("%w: %s", keystore.ErrWalletExists, alias)
+		}
+	}
+	for i, account := range accounts {
+		secret := account.Wallet.Seed
+		if account.Mnemonic != "" {
+			secret = account.Mnemonic
+		}
+		if _, err := storeWallet(aliases[i], secret, account.DerivationPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Wallet set written by provision --manifest
+type manifest struct {
+	CreatedAt time.Time         `json:"createdAt"`
+	Mnemonic  string            `json:"mnemonic,omitempty"` // Mnemonic all accounts derive from, if any
+	Accounts  []manifestAccount `json:"accounts"`
+}
+
+type manifestAccount struct {
+	Alias          string        `json:"alias"`
+	Role           string        `json:"role,omitempty"`
+	Address        types.Address `json:"address"`
+	Secret         string        `json:"secret,omitempty"`
+	DerivationPath string        `json:"derivationPath,omitempty"`
+	Balance        string        `json:"balance,omitempty"`
+	Transactions   []string      `json:"transactions,omitempty"`
+	Error          string        `json:"error,omitempty"`
+}
+
+// Write provisioned accounts with their secrets to a manifest readable only by its owner.
+// An existing file is only replaced when overwrite is set.
+func writeManifest(path string, aliases []string, results []service.ProvisionedAccount, overwrite bool) error {
+	m := manifest{CreatedAt: time.Now().UTC()}
+	for i, result := range results {
+		entry := manifestAccount{
+			Alias:          aliases[i],
+			Role:           result.Role,
+			Address:        result.Wallet.ClassicAddress,
+			Secret:         result.Wallet.Seed,
+			DerivationPath: result.DerivationPath,
+			Balance:        result.Balance,
+		}
+		for _, txResult := range result.Results {
+			entry.Transactions = append(entry.Transactions, txResult.Hash)
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		}
+		m.Mnemonic = result.Mnemonic
+		m.Accounts = append(m.Accounts, entry)
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read the keystore passphrase from XRPL_KEYSTORE_PASSPHRASE, or prompt for it without echo
+func readPassphrase(prompt string, confirm bool) (string, error) {
+	if passphrase := os.Getenv("XRPL_KEYSTORE_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+	if keystorePassphrase != "" {
+		return keystorePassphrase, nil
+	}
+
+	passphrase, err := readHidden(prompt)
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		repeated, err := readHidden("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if repeated != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	keystorePassphrase = passphrase
+	return passphrase, nil
+}
+
+// Standard input shared by every prompt read line by line
+var stdin = bufio.NewReader(os.Stdin)
+
+// Prompt on stderr and read a line from stdin, without echo when stdin is a terminal
+func readHidden(prompt string) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return readLine(prompt)
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	input, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(input), nil
+}
+
+// Create, import, list, export and delete wallets of the encrypted keystore
+func manageWallets() {
+	usage := "Usage: go run main.go wallet <create|import|export|delete> <alias> | wallet list"
+	if len(os.Args) < 3 {
+		fmt.Println(usage)
+		return
+	}
+	store, err := keystore.Open(keystoreDir)
+	if err != nil {
+		log.Fatalf("Failed to open keystore: %v", err)
+	}
+
+	if os.Args[2] == "list" {
+		entries, err := store.List()
+		if err != nil {
+			log.Fatalf("Failed to list wallets: %v", err)
+		}
+		fmt.Printf("Keystore: %s\n", store.Dir())
+		if len(entries) == 0 {
+			fmt.Println("No wallets stored")
+		}
+		for _, entry := range entries {
+			derivation := ""
+			if entry.DerivationPath != "" {
+				derivation = ", mnemonic path " + entry.DerivationPath
+			}
+			fmt.Printf("   %s: %s (created %s%s)\n", entry.Alias, entry.Address, entry.CreatedAt.Format("2006-01-02 15:04:05"), derivation)
+		}
+		return
+	}
+
+	if len(os.Args) < 4 {
+		fmt.Println(usage)
+		return
+	}
+	alias := os.Args[3]
+
+	switch os.Args[2] {
+	case "create":
+		if store.Has(alias) {
+			log.Fatalf("Failed to create wallet: %v: %s", keystore.ErrWalletExists, alias)
+		}
+		passphrase, err := readPassphrase(fmt.Sprintf("New passphrase for wallet %s: ", alias), true)
+		if err != nil {
+			log.Fatalf("Failed to read passphrase: %v", err)
+		}
+		entry, err := store.Create(alias, passphrase)
+		if err != nil {
+			log.Fatalf("Failed to create wallet: %v", err)
+		}
+		fmt.Printf("Wallet created successfully!\nWallet: %s\nAddress: %s\n", entry.Alias, entry.Address)
+
+	case "import":
+		if store.Has(alias) {
+			log.Fatalf("Failed to import wallet: %v: %s", keystore.ErrWalletExists, alias)
+		}
+		secret, err := readHidden(fmt.Sprintf("Secret or mnemonic for wallet %s: ", alias))
+		if err != nil {
+			log.Fatalf("Failed to read secret: %v", err)
+		}
+		// A mnemonic has several words, its wallet is derived at --path or the first account
+		path := ""
+		if len(strings.Fields(secret)) > 1 {
+			if path = flagValue("--path"); path == "" {
+				path = service.DefaultDerivationPath
+			}
+		}
+		entry, err := storeWallet(alias, secret, path)
+		if err != nil {
+			log.Fatalf("Failed to import wallet: %v", err)
+		}
+		fmt.Printf("Wallet imported successfully!\nWallet: %s\nAddress: %s\n", entry.Alias, entry.Address)
+
+	case "export":
+		passphrase, err := readPassphrase(fmt.Sprintf("Passphrase for wallet %s: ", alias), false)
+		if err != nil {
+			log.Fatalf("Failed to read passphrase: %v", err)
+		}
+		secret, err := store.Export(alias, passphrase)
+		if err != nil {
+			log.Fatalf("Failed to export wallet: %v", err)
+		}
+		// Only the secret goes to stdout so it can be redirected to a key file
+		log.Printf("Warning: anyone with the secret of wallet %s controls its account, keep it safe", alias)
+		fmt.Println(secret)
+
+	case "delete":
+		entry, err := store.Lookup(alias)
+		if err != nil {
+			log.Fatalf("Failed to delete wallet: %v", err)
+		}
+		if !hasFlag("--yes") {
+			answer, err := readLine(fmt.Sprintf("Delete wallet %s (%s)? Its account cannot be used without a backup of the secret. Type the alias to confirm: ", alias, entry.Address))
+			if err != nil || strings.TrimSpace(answer) != alias {
+				fmt.Println("Wallet not deleted")
+				return
+			}
+		}
+		if err := store.Delete(alias); err != nil {
+			log.Fatalf("Failed to delete wallet: %v", err)
+		}
+		fmt.Printf("Wallet %s deleted\n", alias)
+
+	default:
+		fmt.Println(usage)
+	}
+}
+
+// Prompt on stderr and read a line from stdin
+func readLine(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("unable to read input: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Sign a prepared transaction with a keystore wallet or the secret stored in a key file, without network access
+func signPreparedTransaction() {
+	if len(os.Args) < 4 {
+		fmt.Println("Usage: go run main.go sign <prepared-file> <wallet-or-key-file>")
+		return
+	}
+
+	data, err := os.ReadFile(os.Args[2])
+	if err != nil {
+		log.Fatalf("Failed to read prepared transaction: %v", err)
+	}
+	var prepared service.EncodedTransaction
+	if err := json.Unmarshal(data, &prepared); err != nil || prepared.TxBlob == "" {
+		log.Fatalf("Invalid prepared transaction file %s", os.Args[2])
+	}
+
+	signer, err := signingWallet(os.Args[3])
+	if err != nil {
+		log.Fatalf("Failed to restore signing wallet: %v", err)
+	}
+
+	signed, err := service.SignOffline(context.Background(), service.NewWalletSigner(&signer), prepared.TxBlob)
+	if err != nil {
+		log.Fatalf("Failed to sign transaction: %v", err)
+	}
+	if account, _ := signed.Transaction["Account"].(string); account != signer.ClassicAddress.String() {
+		log.Printf("Warning: transaction account %s differs from the signing key address %s, it only validates if the key is the account's regular key",
+			account, signer.ClassicAddress)
+	}
+
+	// Output the signed transaction on stdout so it can be redirected to a file for submission
+	signedJSON, _ := json.MarshalIndent(signed, "", "  ")
+	fmt.Println(string(signedJSON))
+}
+
+// Add a signer's signature to a transaction prepared for multisigning, without network access
+func multisignPreparedTransaction() {
+	if len(os.Args) < 4 {
+		fmt.Println("Usage: go run main.go multisign <prepared-file> <wallet-or-key-file>")
+		return
+	}
+
+	txBlob, err := readTransactionBlob(os.Args[2])
+	if err != nil {
+		log.Fatalf("Failed to read prepared transaction: %v", err)
+	}
+	signer, err := multisigner(os.Args[3])
+	if err != nil {
+		log.Fatalf("Failed to restore signing wallet: %v", err)
+	}
+
+	signed, err := service.MultisignOffline(context.Background(), signer, txBlob)
+	if err != nil {
+		log.Fatalf("Failed to sign transaction: %v", err)
+	}
+
+	// Output the partially signed transaction on stdout so it can be redirected to a file for combining
+	signedJSON, _ := json.MarshalIndent(signed, "", "  ")
+	fmt.Println(string(signedJSON))
+}
+
+// Merge the signatures of several signers into a multisigned transaction, without network access
+func combineSignatures() {
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: go run main.go combine <signed-file-or-blob>...")
+		return
+	}
+
+	var txBlobs []string
+	for _, arg := range os.Args[2:] {
+		txBlob, err := readTransactionBlob(arg)
+		if err != nil {
+			log.Fatalf("Failed to read signed transaction: %v", err)
+		}
+		txBlobs = append(txBlobs, txBlob)
+	}
+
+	combined, err := service.CombineSignatures(txBlobs...)
+	if err != nil {
+		log.Fatalf("Failed to combine signatures: %v", err)
+	}
+
+	// Output the multisigned transaction on stdout so it can be redirected to a file for submission
+	combinedJSON, _ := json.MarshalIndent(combined, "", "  ")
+	fmt.Println(string(combinedJSON))
+}
+
+// Restore a signing wallet from the keystore, or from the secret stored in a key file
+func signingWallet(walletOrKeyFile string) (wallet.Wallet, error) {
+	unlocked, found, err := unlockWallet(walletOrKeyFile)
+	if err != nil {
+		return wallet.Wallet{}, err
+	}
+	if found {
+		return *unlocked, nil
+	}
+
+	key, err := os.ReadFile(walletOrKeyFile)
+	if err != nil {
+		return wallet.Wallet{}, err
+	}
+	return wallet.FromSecret(strings.TrimSpace(string(key)))
+}
+
+// Restore the signer of a multisignature, a key of the signing service when named remote:<key>
+func multisigner(walletOrKeyFile string) (service.Signer, error) {
+	if key, ok := strings.CutPrefix(walletOrKeyFile, "remote:"); ok {
+		return service.NewRemoteSigner(context.Background(), signerURL, signerToken, key)
+	}
+	signer, err := signingWallet(walletOrKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return service.NewWalletSigner(&signer), nil
+}
+
+// Read a signed transaction blob from a file written by sign, or take the argument as the blob itself
+func readTransactionBlob(fileOrBlob string) (string, error) {
+	data, err := os.ReadFile(fileOrBlob)
+	if errors.Is(err, os.ErrNotExist) {
+		return fileOrBlob, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var signed service.EncodedTransaction
+	if err := json.Unmarshal(data, &signed); err == nil && signed.TxBlob != "" {
+		return signed.TxBlob, nil
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Check whether an optional flag was passed after the positional arguments
+func hasFlag(name string) bool {
+	for _, arg := range os.Args[2:] {
+		if arg == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Read account addresses separated by whitespace, typically one per line
+func readAddresses(path string) ([]types.Address, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var addresses []types.Address
+	for _, field := range strings.Fields(string(content)) {
+		addresses = append(addresses, types.Address(field))
+	}
+	return addresses, nil
+}
+
+// Print the outcome of each trust line of a bulk update
+func printTrustLineResults(results []service.TrustLineResult) {
+	failed := 0
+	for i, line := range results {
+		if line.Err != nil {
+			failed++
+			fmt.Printf("%d. %s: failed: %v\n", i+1, line.TrustlineAddress, line.Err)
+			continue
+		}
+		if line.Result.DryRun || line.Result.Prepared {
+			fmt.Printf("%d. %s:\n", i+1, line.TrustlineAddress)
+			printTxResult(line.Result)
+			continue
+		}
+		fmt.Printf("%d. %s: %s (ledger %d)\n", i+1, line.TrustlineAddress, line.Result.Hash, line.Result.LedgerIndex)
+	}
+	fmt.Printf("Trust line update finished: %d succeeded, %d failed\n", len(results)-failed, failed)
+}
+
+// Print the outcome of a submitted transaction
+func printTxResult(result *service.TxResult) {
+	if result == nil {
+		return
+	}
+
+	// Output the prepared transaction on stdout so it can be redirected to a file for signing
+	if result.Prepared {
+		preparedJSON, _ := json.MarshalIndent(service.EncodedTransaction{
+			Transaction: result.Transaction,
+			TxBlob:      result.Blob,
+		}, "", "  ")
+		fmt.Println(string(preparedJSON))
+		return
+	}
+
+	if result.DryRun {
+		fmt.Println("Dry run, transaction was signed but not submitted")
+		fmt.Printf("Transaction hash: %s\n", result.Hash)
+		fmt.Printf("Fee: %s drops\n", result.Fee)
+		fmt.Printf("Sequence: %d\n", result.Sequence)
+		fmt.Println("Preflight checks:")
+		for _, check := range result.Checks {
+			status := "PASS"
+			if !check.Passed {
+				status = "FAIL"
+			}
+			fmt.Printf("   [%s] %s: %s\n", status, check.Name, check.Detail)
+		}
+		txJSON, _ := json.MarshalIndent(result.Transaction, "", "  ")
+		fmt.Printf("Transaction JSON:\n%s\n", txJSON)
+		fmt.Printf("Transaction blob: %s\n", result.Blob)
+		return
+	}
+
+	fmt.Printf("Transaction hash: %s\n", result.Hash)
+	if url := network.TransactionURL(result.Hash); url != "" {
+		fmt.Printf("Explorer: %s\n", url)
+	}
+	fmt.Printf("Engine result: %s\n", result.EngineResult)
+	if result.ResultMessage != "" {
+		fmt.Printf("Result message: %s\n", result.ResultMessage)
+	}
+	fmt.Printf("Validated: %t (final: %t)\n", result.Validated, result.Final)
+	if result.Validated {
+		fmt.Printf("Ledger index: %d\n", result.LedgerIndex)
+	}
+	fmt.Printf("Sequence: %d\n", result.Sequence)
+	if result.TicketSequence != 0 {
+		fmt.Printf("Ticket: %d\n", result.TicketSequence)
+	}
+	if result.OpenLedgerFee != "" {
+		fmt.Printf("Fee: %s drops (open ledger fee %s drops)\n", result.Fee, result.OpenLedgerFee)
+	} else {
+		fmt.Printf("Fee: %s drops\n", result.Fee)
+	}
+	fmt.Printf("Node: %s\n", result.Node)
+
+	for _, change := range result.BalanceChanges {
+		fmt.Printf("Balance changes for %s:\n", change.Account)
+		for _, balance := range change.Balances {
+			if balance.Currency == "XRP" {
+				fmt.Printf("   %s XRP\n", balance.Value)
+			} else {
+				fmt.Printf("   %s %s (issuer %s)\n", balance.Value, balance.Currency, balance.Issuer)
+			}
+		}
+	}
+
+	for _, line := range result.TrustLines {
+		fmt.Printf("Trust line %s: %s between %s and %s, balance %s, flags 0x%08X\n",
+			line.Change, line.Currency, line.LowAccount, line.HighAccount, line.Balance, line.Flags)
+	}
+}
+
 func printUsage() {
 	fmt.Println("XRP Token Demo Program - Usage:")
-	fmt.Println("  go run main.go create-account - Create a new XRP account")
-	fmt.Println("  go run main.go fund-devnet-account <account-address> - Fund account with test funds from development network faucet")
-	fmt.Println("  go run main.go config-issuer <account-secret> - Configure issuer account settings")
-	fmt.Println("  go run main.go config-distributor <account-secret> - Configure distributor account settings")
-	fmt.Println("  go run main.go create-trustline <account-secret> <issuer-address> <token-name> <trust-limit> - Create trust line")
-	fmt.Println("  go run main.go transfer-token <sender-secret> <receiver-address> <issuer-address> <token-name> <amount> - Transfer or issue tokens")
+	fmt.Println("  Write commands accept --dry-run to build, sign and check the transaction without submitting it")
+	fmt.Println("  On mainnet (XRPL_NETWORK=mainnet) write commands only submit transactions when passed --confirm-mainnet")
+	fmt.Println("  Wallets are aliases of the encrypted keystore; its passphrase is prompted for or read from XRPL_KEYSTORE_PASSPHRASE")
+	fmt.Println("  Commands taking an account wallet accept the wallet of the account's regular key together with --account <account-address>")
+	fmt.Println("  A wallet named remote:<key> signs with a key of the remote signing service, see go run ./cmd/signer")
+	fmt.Println("  go run main.go wallet create <alias> - Create a new wallet in the keystore")
+	fmt.Println("  go run main.go wallet import <alias> [--path <derivation-path>] - Store an existing secret or mnemonic in the keystore, it is prompted for")
+	fmt.Println("  go run main.go wallet list - List the wallets in the keystore")
+	fmt.Println("  go run main.go wallet export <alias> - Print the secret or mnemonic of a wallet for backup")
+	fmt.Println("  go run main.go wallet delete <alias> [--yes] - Delete a wallet from the keystore")
+	fmt.Println("  go run main.go create-account [alias] [--algorithm ed25519|secp256k1] - Create a new XRP account and store it in the keystore")
+	fmt.Println("  go run main.go create-account [alias] --mnemonic|--restore [--path <derivation-path>] [--count <n>] - Create accounts derived from a new or prompted BIP39 mnemonic")
+	fmt.Println("  go run main.go provision <count> [alias-prefix] [--issuer] [--distributors] [--token <token-name>] [--limit <trust-limit>] [--vanity <address-prefix>] [--algorithm ed25519|secp256k1] [--mnemonic] [--manifest <file>] - Create, fund and set up a set of test accounts")
+	fmt.Println("  go run main.go fund-account <account-address> - Fund account from the faucet of XRPL_FAUCET and wait for the payment to be validated")
+	fmt.Println("  go run main.go fund-devnet-account <account-address> - Fund account from the development network faucet and wait for the payment to be validated")
+	fmt.Println("  go run main.go config-issuer <account-wallet> - Configure issuer account settings")
+	fmt.Println("  go run main.go config-distributor <account-wallet> - Configure distributor account settings")
+	fmt.Println("  go run main.go create-trustline <account-wallet> <issuer-address> <token-name> <trust-limit> [--use-ticket] - Create trust line")
+	fmt.Println("  go run main.go transfer-token <sender-wallet> <receiver-address> <issuer-address> <token-name> <amount> [--use-ticket] - Transfer or issue tokens")
+	fmt.Println("  go run main.go transfer-token-batch <sender-wallet> <issuer-address> <token-name> <transfers-csv> [--use-tickets] - Transfer tokens to many receivers listed as receiver-address,amount lines")
+	fmt.Println("  go run main.go create-tickets <account-wallet> <count> - Set aside sequence numbers as tickets for out-of-order submission")
+	fmt.Println("  go run main.go get-tickets <account-address> - Query tickets available to an account")
+	fmt.Println("  go run main.go freeze-trustline <account-wallet> <trustline-address> <token-name> [--deep] - Freeze a trust line, --deep also stops a frozen line from receiving")
+	fmt.Println("  go run main.go unfreeze-trustline <account-wallet> <trustline-address> <token-name> [--deep] - Unfreeze a trust line, --deep only lifts its deep freeze")
+	fmt.Println("  go run main.go freeze-trustline-batch <account-wallet> <token-name> <addresses-file> - Freeze the trust lines with many counterparties listed one address per line")
+	fmt.Println("  go run main.go unfreeze-trustline-batch <account-wallet> <token-name> <addresses-file> - Unfreeze the trust lines with many counterparties listed one address per line")
+	fmt.Println("  go run main.go authorize-trustline <issuer-wallet> <holder-address> <token-name> - Authorize a holder's trust line for a token of an issuer requiring authorization")
+	fmt.Println("  go run main.go authorize-trustline-batch <issuer-wallet> <token-name> [addresses-file] - Authorize the listed holders' trust lines, or every line waiting for authorization")
+	fmt.Println("  go run main.go get-pending-authorizations <issuer-address> [token-name] - Query the holder trust lines an issuer has not authorized yet")
+	fmt.Println("  go run main.go clawback <issuer-wallet> <holder-address> <token-name> [amount] - Claw back tokens from a holder, the whole balance if no amount is given; the issuer must allow trust line clawback")
+	fmt.Println("  go run main.go global-freeze <issuer-wallet> - Freeze all tokens of an issuer, holders can only trade them with the issuer")
+	fmt.Println("  go run main.go global-unfreeze <issuer-wallet> - Lift the global freeze of an issuer")
+	fmt.Println("  go run main.go set-no-freeze <issuer-wallet> [--yes] - Permanently give up freezing trust lines and lifting a global freeze")
+	fmt.Println("  go run main.go get-freeze-state <issuer-address> - Query the global freeze and NoFreeze settings of an issuer")
+	fmt.Println("  go run main.go set-signer-list <account-wallet> <quorum> [<signer-address>:<weight>...] - Set the keys that multisign for an account, quorum 0 removes them")
+	fmt.Println("  go run main.go get-signer-list <account-address> - Query the signer list of an account")
+	fmt.Println("  go run main.go set-regular-key <account-wallet> <regular-key-address-or-wallet> - Set or rotate the regular key")
+	fmt.Println("  go run main.go remove-regular-key <account-wallet> - Remove the regular key of an account")
+	fmt.Println("  go run main.go disable-master-key <account-wallet> - Stop the master key from signing, requires a regular key or signer list")
+	fmt.Println("  go run main.go enable-master-key <regular-key-wallet> --account <account-address> - Allow the master key to sign again")
+	fmt.Println("  go run main.go get-account-keys <account-address> - Query the regular key, master key status and signer list of an account")
+	fmt.Println("  go run main.go prepare <command> <account-address-or-wallet> [arguments...] [--signers <count>] - Output the unsigned, autofilled transaction of a write command for offline signing or multisigning")
+	fmt.Println("  go run main.go sign <prepared-file> <wallet-or-key-file> - Sign a prepared transaction offline with a keystore wallet or the secret in a key file")
+	fmt.Println("  go run main.go multisign <prepared-file> <wallet-or-key-file> - Add one signer's signature to a transaction prepared with --signers, offline")
+	fmt.Println("  go run main.go combine <signed-file-or-blob>... - Merge signatures from multisign into a transaction ready to submit, offline")
+	fmt.Println("  go run main.go submit <signed-file-or-blob> - Submit a signed transaction and wait for validation")
 	fmt.Println("  go run main.go get-balance <account-address> - Query account XRP balance")
 	fmt.Println("  go run main.go get-tokens <account-address> - Query account token list")
 	fmt.Println("  go run main.go get-trustlines <account-address> - Query all trust line details for account")
+	fmt.Println("  go run main.go nodes - Check health of the configured XRPL nodes")
+	fmt.Println("  go run main.go network - Show the network profile and check the active node against it")
 }
@@ -23,6 +23,41 @@ func TestPrintUsage(t *testing.T) {
 	output := buf.String()
 
 	assert.Contains(t, output, "XRP Token Demo Program - Usage:")
+	assert.Contains(t, output, "go run main.go wallet create")
+	assert.Contains(t, output, "go run main.go wallet import")
+	assert.Contains(t, output, "go run main.go wallet list")
+	assert.Contains(t, output, "go run main.go wallet export")
+	assert.Contains(t, output, "go run main.go wallet delete")
 	assert.Contains(t, output, "go run main.go create-account")
-	assert.Contains(t, output, "go run main.go get-testnet-account")
+	assert.Contains(t, output, "go run main.go provision")
+	assert.Contains(t, output, "go run main.go fund-account")
+	assert.Contains(t, output, "go run main.go fund-devnet-account")
+	assert.Contains(t, output, "go run main.go transfer-token-batch")
+	assert.Contains(t, output, "go run main.go create-tickets")
+	assert.Contains(t, output, "go run main.go get-tickets")
+	assert.Contains(t, output, "go run main.go freeze-trustline")
+	assert.Contains(t, output, "go run main.go unfreeze-trustline")
+	assert.Contains(t, output, "go run main.go freeze-trustline-batch")
+	assert.Contains(t, output, "go run main.go unfreeze-trustline-batch")
+	assert.Contains(t, output, "go run main.go authorize-trustline")
+	assert.Contains(t, output, "go run main.go authorize-trustline-batch")
+	assert.Contains(t, output, "go run main.go get-pending-authorizations")
+	assert.Contains(t, output, "go run main.go clawback")
+	assert.Contains(t, output, "go run main.go global-freeze")
+	assert.Contains(t, output, "go run main.go set-no-freeze")
+	assert.Contains(t, output, "go run main.go get-freeze-state")
+	assert.Contains(t, output, "go run main.go set-signer-list")
+	assert.Contains(t, output, "go run main.go get-signer-list")
+	assert.Contains(t, output, "go run main.go set-regular-key")
+	assert.Contains(t, output, "go run main.go remove-regular-key")
+	assert.Contains(t, output, "go run main.go disable-master-key")
+	assert.Contains(t, output, "go run main.go enable-master-key")
+	assert.Contains(t, output, "go run main.go get-account-keys")
+	assert.Contains(t, output, "go run main.go prepare")
+	assert.Contains(t, output, "go run main.go sign")
+	assert.Contains(t, output, "go run main.go multisign")
+	assert.Contains(t, output, "go run main.go combine")
+	assert.Contains(t, output, "go run main.go submit")
+	assert.Contains(t, output, "go run main.go nodes")
+	assert.Contains(t, output, "go run main.go network")
 }
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// AuthorizeTrustLine calls AuthorizeTrustLineContext with a background context
+func (s *XRPLService) AuthorizeTrustLine(issuer Signer, holderAddress types.Address, tokenName string) (*TxResult, error) {
+	return s.AuthorizeTrustLineContext(context.Background(), issuer, holderAddress, tokenName)
+}
+
+// AuthorizeTrustLineContext authorizes a holder's trust line for a token of the signer, which must require authorization.
+// Authorization cannot be revoked, freeze the line to stop the holder from using it.
+func (s *XRPLService) AuthorizeTrustLineContext(ctx context.Context, issuer Signer, holderAddress types.Address, tokenName string) (*TxResult, error) {
+	// Dry runs report an issuer without RequireAuth as a failed preflight check
+	if !s.dryRun && !s.prepareOnly {
+		if err := s.checkAuthRequired(ctx, issuer.Address()); err != nil {
+			return nil, fmt.Errorf("unable to authorize trust line: %w", err)
+		}
+	}
+	result, err := s.updateTrustLineFlags(ctx, issuer, holderAddress, tokenName, func(line *TrustLine, trustSet *transaction.TrustSet) error {
+		trustSet.SetSetAuthFlag()
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("unable to authorize trust line: %w", err)
+	}
+	return result, nil
+}
+
+// AuthorizeTrustLines calls AuthorizeTrustLinesContext with a background context
+func (s *XRPLService) AuthorizeTrustLines(issuer Signer, holderAddresses []types.Address, tokenName string) []TrustLineResult {
+	return s.AuthorizeTrustLinesContext(context.Background(), issuer, holderAddresses, tokenName)
+}
+
+// AuthorizeTrustLinesContext authorizes the trust lines of many holders, pipelined like TransferTokens.
+// A failed line does not stop the others, results are in the order of holderAddresses.
+func (s *XRPLService) AuthorizeTrustLinesContext(ctx context.Context, issuer Signer, holderAddresses []types.Address, tokenName string) []TrustLineResult {
+	return s.updateTrustLines(ctx, holderAddresses, func(address types.Address) (*TxResult, error) {
+		return s.AuthorizeTrustLineContext(ctx, issuer, address, tokenName)
+	})
+}
+
+// PendingAuthorizations calls PendingAuthorizationsContext with a background context
+func (s *XRPLService) PendingAuthorizations(issuer types.Address, tokenName string) ([]TrustLine, error) {
+	return s.PendingAuthorizationsContext(context.Background(), issuer, tokenName)
+}
+
+// PendingAuthorizationsContext lists the holder trust lines of an issuer's token that the issuer has not authorized yet,
+// as seen from the issuer. An empty tokenName lists pending lines of every token. Trust lines of an issuer
+// that does not require authorization are never pending, the error matches ErrAuthNotRequired.
+func (s *XRPLService) PendingAuthorizationsContext(ctx context.Context, issuer types.Address, tokenName string) ([]TrustLine, error) {
+	if err := s.checkAuthRequired(ctx, issuer); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.accountLines(ctx, issuer, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account trust lines: %w", err)
+	}
+
+	return unauthorizedLines(resp.Lines, tokenName), nil
+}
+
+// Refuse to authorize trust lines of an issuer whose account does not require authorization
+func (s *XRPLService) checkAuthRequired(ctx context.Context, issuer types.Address) error {
+	account, err := s.ledgerAccount(ctx, issuer.String())
+	if err != nil {
+		return fmt.Errorf("unable to get issuer account: %w", err)
+	}
+	if account == nil {
+		return fmt.Errorf("%w: %s", ErrAccountNotFound, issuer)
+	}
+	return authRequired(account)
+}
+
+// Check that an issuer has enabled RequireAuth, otherwise its trust lines need no authorization
+func authRequired(issuer *ledger.AccountRoot) error {
+	if issuer.Flags&lsfRequireAuth == 0 {
+		return fmt.Errorf("%w: %s has not enabled RequireAuth, its trust lines need no authorization", ErrAuthNotRequired, issuer.Account)
+	}
+	return nil
+}
+
+// Select the trust lines of a token the account has not authorized, of every token if tokenName is empty
+func unauthorizedLines(lines []TrustLine, tokenName string) []TrustLine {
+	pending := []TrustLine{}
+	for _, line := range lines {
+		if !line.Authorized && (tokenName == "" || line.Currency == tokenName) {
+			pending = append(pending, line)
+		}
+	}
+	return pending
+}
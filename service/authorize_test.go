@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUnauthorizedLines tests selecting the trust lines awaiting authorization
+func TestUnauthorizedLines(t *testing.T) {
+	lines := []TrustLine{
+		{Account: "rPending", Currency: "USD"},
+		{Account: "rApproved", Currency: "USD", Authorized: true},
+		{Account: "rOther", Currency: "EUR"},
+	}
+
+	pending := unauthorizedLines(lines, "USD")
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "rPending", pending[0].Account)
+
+	assert.Len(t, unauthorizedLines(lines, ""), 2)
+	assert.Empty(t, unauthorizedLines(nil, "USD"))
+}
+
+// TestAuthRequired tests that only issuers requiring authorization have trust lines to authorize
+func TestAuthRequired(t *testing.T) {
+	assert.ErrorIs(t, authRequired(&ledger.AccountRoot{Account: "rIssuer"}), ErrAuthNotRequired)
+	assert.NoError(t, authRequired(&ledger.AccountRoot{Account: "rIssuer", Flags: lsfRequireAuth}))
+	assert.ErrorIs(t, &TransactionError{EngineResult: "tefNO_AUTH_REQUIRED"}, ErrAuthNotRequired)
+}
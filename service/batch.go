@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// Maximum number of batch transactions awaiting validation at the same time
+const maxPipelineDepth = 50
+
+// TransferResult is the outcome of a single transfer in a batch
+type TransferResult struct {
+	Options *TransferTokenOptions // Transfer that was requested
+	Result  *TxResult             // Transaction result, nil if the transfer was not submitted
+	Err     error                 // Reason the transfer failed, nil on success
+}
+
+// TransferTokens calls TransferTokensContext with a background context
+func (s *XRPLService) TransferTokens(sender Signer, transfers []*TransferTokenOptions) []TransferResult {
+	return s.TransferTokensContext(context.Background(), sender, transfers)
+}
+
+// TransferTokensContext sends many token payments from one account.
+// Sequences are allocated locally and payments are submitted without waiting for earlier ones
+// to be validated, keeping up to maxPipelineDepth payments in flight. Results are in the order of transfers.
+func (s *XRPLService) TransferTokensContext(ctx context.Context, sender Signer, transfers []*TransferTokenOptions) []TransferResult {
+	results := make([]TransferResult, len(transfers))
+	slots := make(chan struct{}, maxPipelineDepth)
+	var wg sync.WaitGroup
+
+	for i, options := range transfers {
+		results[i].Options = options
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *TransferResult) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result.Result, result.Err = s.TransferTokenContext(ctx, sender, result.Options)
+		}(&results[i])
+	}
+
+	wg.Wait()
+	return results
+}
+
+// TrustLineResult is the outcome of updating a single trust line in a bulk operation
+type TrustLineResult struct {
+	TrustlineAddress types.Address // Counterparty of the trust line
+	Result           *TxResult     // Transaction result, nil if the line was not updated
+	Err              error         // Reason the update failed, nil on success
+}
+
+// Update the trust lines with many counterparties, keeping up to maxPipelineDepth transactions in flight.
+// Results are in the order of trustlineAddresses.
+func (s *XRPLService) updateTrustLines(ctx context.Context, trustlineAddresses []types.Address, update func(address types.Address) (*TxResult, error)) []TrustLineResult {
+	results := make([]TrustLineResult, len(trustlineAddresses))
+	slots := make(chan struct{}, maxPipelineDepth)
+	var wg sync.WaitGroup
+
+	for i, address := range trustlineAddresses {
+		results[i].TrustlineAddress = address
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *TrustLineResult) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result.Result, result.Err = update(result.TrustlineAddress)
+		}(&results[i])
+	}
+
+	wg.Wait()
+	return results
+}
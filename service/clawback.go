@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	addresscodec "github.com/Peersyst/xrpl-go/address-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// Clawback options
+type ClawbackOptions struct {
+	HolderAddress types.Address `json:"holderAddress"` // Holder the tokens are clawed back from
+	TokenName     string        `json:"tokenName"`     // Token name
+	Amount        string        `json:"amount"`        // Amount to claw back, empty for the whole balance
+}
+
+// ClawbackResult is the outcome of a clawback with the holder's balance around it
+type ClawbackResult struct {
+	*TxResult
+	BalanceBefore string `json:"balanceBefore"`          // Holder balance before the clawback
+	BalanceAfter  string `json:"balanceAfter,omitempty"` // Holder balance once the clawback is validated, empty if it was not submitted
+}
+
+// Clawback calls ClawbackContext with a background context
+func (s *XRPLService) Clawback(issuer Signer, options *ClawbackOptions) (*ClawbackResult, error) {
+	return s.ClawbackContext(context.Background(), issuer, options)
+}
+
+// ClawbackContext takes tokens issued by the signer's account back from a holder, which requires the issuer to have
+// enabled Allow Trust Line Clawback before issuing. Amounts above the holder's balance claw back the whole balance.
+func (s *XRPLService) ClawbackContext(ctx context.Context, issuer Signer, options *ClawbackOptions) (*ClawbackResult, error) {
+	// Return error if no options provided
+	if options == nil {
+		return nil, fmt.Errorf("clawback options must be provided")
+	}
+	if !addresscodec.IsValidClassicAddress(options.HolderAddress.String()) {
+		return nil, fmt.Errorf("invalid holder address %q", options.HolderAddress)
+	}
+	if options.HolderAddress == issuer.Address() {
+		return nil, fmt.Errorf("issuer %s cannot claw back from itself", issuer.Address())
+	}
+
+	// Balance before the clawback, which is also the amount when none is given
+	line, err := s.ledgerTrustLine(ctx, options.HolderAddress.String(), issuer.Address().String(), options.TokenName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get holder trust line: %w", err)
+	}
+	if line == nil {
+		return nil, fmt.Errorf("%w: %s has no %s trust line with %s", ErrNoTrustLine, options.HolderAddress, options.TokenName, issuer.Address())
+	}
+	amount := options.Amount
+	if amount == "" {
+		if balance, _ := strconv.ParseFloat(line.Balance, 64); balance <= 0 {
+			return nil, fmt.Errorf("%w: %s holds no %s", ErrNothingToClawBack, options.HolderAddress, options.TokenName)
+		}
+		amount = line.Balance
+	}
+
+	// Refuse clawbacks the ledger would reject for a fee, dry runs report them as failed preflight checks
+	if !s.dryRun && !s.prepareOnly {
+		issuerAccount, err := s.ledgerAccount(ctx, issuer.Address().String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to get issuer account: %w", err)
+		}
+		if issuerAccount == nil {
+			return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, issuer.Address())
+		}
+		if err := clawbackRefusal(issuerAccount, options.HolderAddress, options.TokenName, line); err != nil {
+			return nil, err
+		}
+	}
+
+	// The issuer field of a clawback amount names the holder
+	clawback := &transaction.Clawback{
+		BaseTx: transaction.BaseTx{
+			Account: issuer.Address(),
+		},
+		Amount: types.IssuedCurrencyAmount{
+			Currency: options.TokenName,
+			Issuer:   options.HolderAddress,
+			Value:    amount,
+		},
+	}
+
+	// Autofill, sign, submit and track transaction to its final outcome
+	result := &ClawbackResult{BalanceBefore: line.Balance}
+	result.TxResult, err = s.SubmitTransactionContext(ctx, issuer, clawback.Flatten())
+	if err != nil {
+		return result, fmt.Errorf("unable to claw back tokens: %w", err)
+	}
+	if !result.Validated {
+		return result, nil
+	}
+
+	line, err = s.ledgerTrustLine(ctx, options.HolderAddress.String(), issuer.Address().String(), options.TokenName)
+	if err != nil {
+		return result, fmt.Errorf("unable to get holder balance after clawback: %w", err)
+	}
+	// A trust line left at its default state is removed with the balance
+	result.BalanceAfter = "0"
+	if line != nil {
+		result.BalanceAfter = line.Balance
+	}
+	return result, nil
+}
+
+// Refuse a clawback by an issuer that has not enabled Allow Trust Line Clawback, or from a holder without a balance
+func clawbackRefusal(issuer *ledger.AccountRoot, holder types.Address, currency string, line *TrustLine) error {
+	if issuer.Flags&lsfAllowTrustLineClawback == 0 {
+		// The ledger refuses to enable clawback once NoFreeze is set
+		if issuer.Flags&lsfNoFreeze != 0 {
+			return fmt.Errorf("%w: %s has set NoFreeze and can never enable Allow Trust Line Clawback", ErrClawbackNotEnabled, issuer.Account)
+		}
+		return fmt.Errorf("%w: %s has not enabled Allow Trust Line Clawback", ErrClawbackNotEnabled, issuer.Account)
+	}
+	if check := holderBalanceCheck(holder.String(), currency, line); !check.Passed {
+		return fmt.Errorf("%w: %s", ErrNothingToClawBack, check.Detail)
+	}
+	return nil
+}
+
+// Check that the holder has a positive balance of the issuer's token to claw back
+func holderBalanceCheck(holder, currency string, line *TrustLine) PreflightCheck {
+	if line == nil {
+		return PreflightCheck{Name: "holder balance", Passed: false, Detail: fmt.Sprintf("%s has no %s trust line", holder, currency)}
+	}
+	balance, _ := strconv.ParseFloat(line.Balance, 64)
+	return PreflightCheck{Name: "holder balance", Passed: balance > 0, Detail: fmt.Sprintf("%s holds %s %s", holder, line.Balance, currency)}
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/config"
+	gorillaws "github.com/gorilla/websocket"
+)
+
+const (
+	// Initial delay before the first reconnection attempt
+	defaultMinBackoff = 500 * time.Millisecond
+	// Upper bound for the delay between reconnection attempts
+	defaultMaxBackoff = 8 * time.Second
+	// Number of connection attempts before giving up
+	defaultMaxConnectAttempts = 5
+)
+
+// ErrConnectionClosed is returned when the connection manager has been closed
+var ErrConnectionClosed = errors.New("connection manager is closed")
+
+// ConnectionManager keeps a single long-lived connection to an XRPL node from the node pool,
+// failing over to the next healthy node when the active one drops or becomes unhealthy.
+// The underlying websocket client matches responses to requests by reading from
+// a single channel, so requests are serialized; all methods are safe for concurrent use.
+type ConnectionManager struct {
+	pool *config.NodePool
+
+	// Semaphore guarding the fields below, a channel so that waiting for it can be cancelled
+	sem    chan struct{}
+	client *websocket.Client
+	node   string
+	closed bool
+
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	maxAttempts int
+}
+
+// NewConnectionManager creates a connection manager for the given node pool.
+// The connection is opened lazily on first use.
+func NewConnectionManager(pool *config.NodePool) *ConnectionManager {
+	return &ConnectionManager{
+		pool:        pool,
+		sem:         make(chan struct{}, 1),
+		minBackoff:  defaultMinBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		maxAttempts: defaultMaxConnectAttempts,
+	}
+}
+
+// Do runs fn with exclusive access to a connected client and returns the URL of the node that served it.
+// If fn fails because the connection dropped, the manager fails over and runs fn once more.
+// Errors are wrapped with the matching sentinel error, such as ErrConnectionLost or ErrAccountNotFound.
+// Do returns as soon as ctx is done; a request already sent keeps the connection until it completes.
+func (m *ConnectionManager) Do(ctx context.Context, fn func(client *websocket.Client) error) (string, error) {
+	if err := m.lock(ctx); err != nil {
+		return "", err
+	}
+
+	type result struct {
+		node string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer m.unlock()
+		node, err := m.do(ctx, fn)
+		done <- result{node, classifyError(err)}
+	}()
+
+	select {
+	case r := <-done:
+		return r.node, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Run fn on a connected client, failing over once on connection errors. Must be called with the lock held.
+func (m *ConnectionManager) do(ctx context.Context, fn func(client *websocket.Client) error) (string, error) {
+	if m.closed {
+		return "", ErrConnectionClosed
+	}
+
+	if err := m.connect(ctx); err != nil {
+		return "", err
+	}
+
+	err := fn(m.client)
+	if err == nil || !isConnectionError(err) {
+		return m.node, err
+	}
+
+	// Connection dropped while running the request, fail over and retry once
+	m.pool.MarkUnhealthy(m.node, err)
+	m.disconnect()
+	if err := m.connect(ctx); err != nil {
+		return "", err
+	}
+
+	return m.node, fn(m.client)
+}
+
+// Node returns the URL of the node currently in use, empty if not connected
+func (m *ConnectionManager) Node() string {
+	m.lock(context.Background())
+	defer m.unlock()
+
+	return m.node
+}
+
+// Close closes the connection; subsequent calls to Do fail with ErrConnectionClosed
+func (m *ConnectionManager) Close() error {
+	m.lock(context.Background())
+	defer m.unlock()
+
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
+	return m.disconnect()
+}
+
+// Acquire exclusive access to the connection, giving up when ctx is done
+func (m *ConnectionManager) lock(ctx context.Context) error {
+	select {
+	case m.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release exclusive access to the connection
+func (m *ConnectionManager) unlock() {
+	<-m.sem
+}
+
+// Connect to the first reachable node in the pool, retrying with exponential backoff. Must be called with the lock held.
+func (m *ConnectionManager) connect(ctx context.Context) error {
+	if m.client != nil && m.client.IsConnected() {
+		// Fail over proactively when health checks report the active node as unhealthy
+		candidates := m.pool.Candidates()
+		if m.pool.IsHealthy(m.node) || candidates[0] == m.node || !m.pool.IsHealthy(candidates[0]) {
+			return nil
+		}
+		log.Printf("XRPL node %s is unhealthy, failing over", m.node)
+		m.disconnect()
+	}
+
+	backoff := m.minBackoff
+	var err error
+	for attempt := 1; attempt <= m.maxAttempts; attempt++ {
+		for _, url := range m.pool.Candidates() {
+			client := m.pool.Client(url)
+			if !client.IsConnected() {
+				if err = client.Connect(); err != nil {
+					m.pool.MarkUnhealthy(url, err)
+					continue
+				}
+			}
+
+			if m.node != "" && m.node != url {
+				log.Printf("Failed over from XRPL node %s to %s", m.node, url)
+			}
+			m.client, m.node = client, url
+			return nil
+		}
+
+		if attempt == m.maxAttempts {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+		if backoff > m.maxBackoff {
+			backoff = m.maxBackoff
+		}
+	}
+
+	return fmt.Errorf("%w: unable to connect after %d attempts: %w", ErrConnectionLost, m.maxAttempts, err)
+}
+
+// Disconnect the active client. Must be called with the lock held.
+func (m *ConnectionManager) disconnect() error {
+	if m.client == nil || !m.client.IsConnected() {
+		return nil
+	}
+	return m.client.Disconnect()
+}
+
+// Check whether an error indicates that the websocket connection is no longer usable
+func isConnectionError(err error) bool {
+	if errors.Is(err, websocket.ErrNotConnectedToServer) ||
+		errors.Is(err, websocket.ErrNotConnected) ||
+		errors.Is(err, websocket.ErrRequestTimedOut) ||
+		errors.Is(err, gorillaws.ErrCloseSent) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var closeErr *gorillaws.CloseError
+	if errors.As(err, &closeErr) {
+		return true
+	}
+
+	var netErr *net.OpError
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for common XRPL failures, use errors.Is to check for them.
+// Failed transactions are reported as *TransactionError, which matches the sentinel for its engine result.
+var (
+	// ErrAccountNotFound is returned when an account, including a payment destination, does not exist in the ledger
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrInsufficientReserve is returned when an account does not hold enough XRP for the reserve of a new ledger object
+	ErrInsufficientReserve = errors.New("insufficient XRP reserve")
+	// ErrNoTrustLine is returned when the account has no trust line for the token
+	ErrNoTrustLine = errors.New("no trust line for token")
+	// ErrLineFrozen is returned when the trust line or the token is frozen
+	ErrLineFrozen = errors.New("trust line is frozen")
+	// ErrLineNotFrozen is returned when a deep freeze is requested on a trust line that is not frozen
+	ErrLineNotFrozen = errors.New("trust line is not frozen")
+	// ErrGlobalFreeze is returned when a token payment between holders is refused because the issuer froze all its tokens
+	ErrGlobalFreeze = errors.New("token is globally frozen")
+	// ErrRequiresAuthorization is returned when the issuer has not authorized the trust line
+	ErrRequiresAuthorization = errors.New("trust line requires authorization")
+	// ErrAuthNotRequired is returned when trust lines are authorized for an issuer that does not require authorization
+	ErrAuthNotRequired = errors.New("issuer does not require authorization")
+	// ErrClawbackNotEnabled is returned when the issuer has not enabled Allow Trust Line Clawback
+	ErrClawbackNotEnabled = errors.New("trust line clawback not enabled")
+	// ErrNothingToClawBack is returned when the holder has no balance of the issuer's token to claw back
+	ErrNothingToClawBack = errors.New("nothing to claw back")
+	// ErrPathDry is returned when a payment cannot deliver the requested amount
+	ErrPathDry = errors.New("payment path has insufficient liquidity")
+	// ErrFeeTooHigh is returned when the transaction cost exceeds the allowed maximum
+	ErrFeeTooHigh = errors.New("transaction fee too high")
+	// ErrConnectionLost is returned when no XRPL node could be reached
+	ErrConnectionLost = errors.New("connection to XRP Ledger lost")
+	// ErrNoTickets is returned when a transaction should use a ticket but the account has none left
+	ErrNoTickets = errors.New("no tickets available")
+	// ErrNotValidated is returned when a submitted transaction was not validated in time
+	ErrNotValidated = errors.New("transaction not validated in time")
+	// ErrSignerUnavailable is returned when the remote signing service cannot be reached
+	ErrSignerUnavailable = errors.New("signing service unavailable")
+	// ErrMainnetNotConfirmed is returned when a transaction would be submitted to mainnet without explicit confirmation
+	ErrMainnetNotConfirmed = errors.New("mainnet write not confirmed")
+)
+
+// Sentinel errors matched by each engine result
+var engineResultErrors = map[string]error{
+	"tecNO_DST":                ErrAccountNotFound,
+	"tecNO_DST_INSUF_XRP":      ErrAccountNotFound,
+	"tecNO_ISSUER":             ErrAccountNotFound,
+	"terNO_ACCOUNT":            ErrAccountNotFound,
+	"tecINSUF_RESERVE_LINE":    ErrInsufficientReserve,
+	"tecINSUF_RESERVE_OFFER":   ErrInsufficientReserve,
+	"tecINSUFFICIENT_RESERVE":  ErrInsufficientReserve,
+	"tecNO_LINE_INSUF_RESERVE": ErrInsufficientReserve,
+	"tecNO_LINE":               ErrNoTrustLine,
+	"terNO_LINE":               ErrNoTrustLine,
+	"tecFROZEN":                ErrLineFrozen,
+	"tecNO_AUTH":               ErrRequiresAuthorization,
+	"terNO_AUTH":               ErrRequiresAuthorization,
+	"tefNO_AUTH_REQUIRED":      ErrAuthNotRequired,
+	"tecPATH_DRY":              ErrPathDry,
+	"tecPATH_PARTIAL":          ErrPathDry,
+	"tefMAX_LEDGER":            ErrNotValidated,
+}
+
+// Is reports whether the engine result of the transaction corresponds to target
+func (e *TransactionError) Is(target error) bool {
+	sentinel, ok := engineResultErrors[e.EngineResult]
+	return ok && sentinel == target
+}
+
+// IsTemporary reports whether an operation that failed with err may succeed if retried later.
+// Connection losses, an unreachable signing service, fee spikes, validation timeouts and tentative transaction results are temporary;
+// a retry after ErrNotValidated must first check whether the original transaction was applied.
+func IsTemporary(err error) bool {
+	var txErr *TransactionError
+	if errors.As(err, &txErr) && !txErr.Final {
+		return true
+	}
+	return errors.Is(err, ErrConnectionLost) ||
+		errors.Is(err, ErrSignerUnavailable) ||
+		errors.Is(err, ErrFeeTooHigh) ||
+		errors.Is(err, ErrNotValidated) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// Wrap an error returned by the XRPL client with the matching sentinel error
+func classifyError(err error) error {
+	switch {
+	case err == nil || errors.Is(err, ErrConnectionLost):
+		return err
+	case isXRPLError(err, "actNotFound"):
+		return fmt.Errorf("%w: %w", ErrAccountNotFound, err)
+	case isXRPLError(err, "highFee"):
+		return fmt.Errorf("%w: %w", ErrFeeTooHigh, err)
+	case isConnectionError(err):
+		return fmt.Errorf("%w: %w", ErrConnectionLost, err)
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTransactionErrorIs tests matching failed transactions against sentinel errors
+func TestTransactionErrorIs(t *testing.T) {
+	err := fmt.Errorf("token payment failed: %w", &TransactionError{EngineResult: "tecPATH_DRY", Final: true})
+	assert.ErrorIs(t, err, ErrPathDry)
+	assert.NotErrorIs(t, err, ErrNoTrustLine)
+	assert.False(t, IsTemporary(err))
+
+	assert.ErrorIs(t, &TransactionError{EngineResult: "tecNO_LINE"}, ErrNoTrustLine)
+	assert.ErrorIs(t, &TransactionError{EngineResult: "tecFROZEN"}, ErrLineFrozen)
+	assert.ErrorIs(t, &TransactionError{EngineResult: "tecNO_AUTH"}, ErrRequiresAuthorization)
+	assert.True(t, IsTemporary(&TransactionError{EngineResult: "terQUEUED"}))
+}
+
+// TestClassifyError tests wrapping XRPL client errors with sentinel errors
+func TestClassifyError(t *testing.T) {
+	err := classifyError(&websocket.ErrorWebsocketClientXrplResponse{Type: "actNotFound"})
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+	assert.False(t, IsTemporary(err))
+
+	err = classifyError(io.EOF)
+	assert.ErrorIs(t, err, ErrConnectionLost)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.True(t, IsTemporary(err))
+
+	assert.ErrorIs(t, classifyError(&websocket.ErrorWebsocketClientXrplResponse{Type: "highFee"}), ErrFeeTooHigh)
+	assert.True(t, IsTemporary(fmt.Errorf("%w: %w", ErrNotValidated, context.DeadlineExceeded)))
+
+	other := errors.New("other")
+	assert.Equal(t, other, classifyError(other))
+	assert.Nil(t, classifyError(nil))
+}
@@ -1,12 +1,140 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"time"
 
 	"github.com/Peersyst/xrpl-go/xrpl/faucet"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/config"
 )
 
+// Faucets of the public test networks
+const (
+	DevnetFaucetURL  = "https://" + faucet.DevnetFaucetHost + faucet.DevnetFaucetPath
+	TestnetFaucetURL = "https://" + faucet.TestnetFaucetHost + faucet.TestnetFaucetPath
+)
+
+const (
+	// Interval between checks while waiting for a faucet payment to be validated
+	fundingPollInterval = 2 * time.Second
+	// Time a faucet payment has to be validated before waiting for it gives up
+	fundingTimeout = 2 * time.Minute
+)
+
+// FaucetProvider funds accounts with test XRP
+type FaucetProvider interface {
+	// Fund pays test XRP to an account, returning the amount paid in drops, zero if unknown,
+	// and the hash of the payment, empty if unknown
+	Fund(ctx context.Context, address types.Address) (amountDrops uint64, txHash string, err error)
+}
+
+// HTTPFaucet is a faucet service, such as those of the public test networks
+type HTTPFaucet struct {
+	URL string
+}
+
+// NewHTTPFaucet creates a provider for the faucet service at url
+func NewHTTPFaucet(url string) *HTTPFaucet {
+	return &HTTPFaucet{URL: url}
+}
+
+// Fund asks the faucet service to fund an account
+func (f *HTTPFaucet) Fund(ctx context.Context, address types.Address) (uint64, string, error) {
+	resp, err := requestFunding(ctx, f.URL, address)
+	if err != nil {
+		return 0, "", err
+	}
+	// Faucets report the amount in XRP
+	amountXRP, _ := resp.Amount.Float64()
+	return uint64(math.Round(amountXRP * 1e6)), resp.TransactionHash, nil
+}
+
+// GenesisFunder pays test XRP from an account of the ledger itself, such as the genesis account of a
+// standalone rippled, so test accounts can be funded without a faucet service
+type GenesisFunder struct {
+	service     *XRPLService
+	funder      Signer
+	amountDrops uint64
+}
+
+// NewGenesisFunder creates a provider paying amountDrops from the account of funder for each account
+func NewGenesisFunder(s *XRPLService, funder Signer, amountDrops uint64) *GenesisFunder {
+	return &GenesisFunder{service: s, funder: funder, amountDrops: amountDrops}
+}
+
+// Fund pays the account from the funder and waits for the payment to be validated
+func (f *GenesisFunder) Fund(ctx context.Context, address types.Address) (uint64, string, error) {
+	payment := &transaction.Payment{
+		BaseTx: transaction.BaseTx{
+			Account: f.funder.Address(),
+		},
+		Amount:      types.XRPCurrencyAmount(f.amountDrops),
+		Destination: address,
+	}
+	result, err := f.service.SubmitTransactionContext(ctx, f.funder, payment.Flatten())
+	if err != nil {
+		return 0, "", fmt.Errorf("genesis funding payment failed: %w", err)
+	}
+	return f.amountDrops, result.Hash, nil
+}
+
+// Create the faucet provider selected by configuration
+func newFaucetProvider(s *XRPLService, cfg *config.Config) (FaucetProvider, error) {
+	switch cfg.Faucet {
+	case "":
+		return nil, nil
+	case config.FaucetDevnet:
+		return NewHTTPFaucet(DevnetFaucetURL), nil
+	case config.FaucetTestnet:
+		return NewHTTPFaucet(TestnetFaucetURL), nil
+	case config.FaucetGenesis:
+		funder, err := wallet.FromSecret(cfg.GenesisSecret)
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis faucet secret: %w", err)
+		}
+		return NewGenesisFunder(s, NewWalletSigner(&funder), cfg.FaucetAmountDrops), nil
+	}
+	return NewHTTPFaucet(cfg.Faucet), nil
+}
+
+// Faucet returns the faucet provider funding accounts, nil if none is configured
+func (s *XRPLService) Faucet() FaucetProvider {
+	return s.faucet
+}
+
+// WithFaucet returns a service funding accounts with the given faucet provider
+func (s *XRPLService) WithFaucet(faucet FaucetProvider) *XRPLService {
+	withFaucet := *s
+	withFaucet.faucet = faucet
+	return &withFaucet
+}
+
+// FundingResult is the outcome of a faucet payment once it is validated
+type FundingResult struct {
+	Address types.Address `json:"address"` // Funded account
+	Amount  string        `json:"amount"`  // XRP paid by the faucet
+	Balance string        `json:"balance"` // XRP balance of the account in the validated ledger
+	TxHash  string        `json:"txHash"`  // Hash of the faucet payment, empty if the faucet does not report it
+}
+
+// Response of the XRP Ledger test network faucets
+type faucetResponse struct {
+	Amount          json.Number `json:"amount"`
+	TransactionHash string      `json:"transactionHash"`
+}
+
 // FundDevnetAccount funds an existing address with test funds from the faucet
 func FundDevnetAccount(address types.Address) (bool, error) {
 	devnetFaucet := faucet.NewDevnetFaucetProvider()
@@ -28,3 +156,109 @@ func FundTestnetAccount(address types.Address) (bool, error) {
 
 	return true, nil
 }
+
+// FundAndWait calls FundAndWaitContext with a background context
+func (s *XRPLService) FundAndWait(address types.Address) (*FundingResult, error) {
+	return s.FundAndWaitContext(context.Background(), address)
+}
+
+// FundAndWaitContext funds an account from the configured faucet and blocks until the payment is in a
+// validated ledger, that is until the account exists with its previous balance plus the funded amount.
+func (s *XRPLService) FundAndWaitContext(ctx context.Context, address types.Address) (*FundingResult, error) {
+	if s.faucet == nil {
+		return nil, fmt.Errorf("failed to fund account: no faucet configured")
+	}
+	before, _, err := s.validatedBalance(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund account: %w", err)
+	}
+
+	amount, txHash, err := s.faucet.Fund(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund account: %w", err)
+	}
+
+	// Without the funded amount, wait for any increase
+	balance, err := s.waitForBalance(ctx, address, before+max(amount, 1))
+	if err != nil {
+		return nil, err
+	}
+	return &FundingResult{
+		Address: address,
+		Amount:  formatXRP(amount),
+		Balance: formatXRP(balance),
+		TxHash:  txHash,
+	}, nil
+}
+
+// Ask a faucet to fund an account
+func requestFunding(ctx context.Context, faucetURL string, address types.Address) (*faucetResponse, error) {
+	payload, err := json.Marshal(map[string]string{"destination": address.String(), "userAgent": faucet.UserAgent})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, faucetURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("faucet responded with status %d", httpResp.StatusCode)
+	}
+
+	var resp faucetResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("invalid faucet response: %w", err)
+	}
+	return &resp, nil
+}
+
+// Poll an account until it exists in a validated ledger with a balance of at least minDrops, and return the balance
+func (s *XRPLService) waitForBalance(ctx context.Context, address types.Address, minDrops uint64) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, fundingTimeout)
+	defer cancel()
+	ticker := time.NewTicker(fundingPollInterval)
+	defer ticker.Stop()
+
+	for {
+		balance, found, err := s.validatedBalance(ctx, address)
+		if err != nil {
+			return 0, fmt.Errorf("unable to check funding of %s: %w", address, err)
+		}
+		if found && balance >= minDrops {
+			return balance, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("account %s was not funded: %w", address, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Get the XRP balance of an account in drops from the validated ledger; found is false if the account does not exist
+func (s *XRPLService) validatedBalance(ctx context.Context, address types.Address) (drops uint64, found bool, err error) {
+	var resp *account.InfoResponse
+	_, err = s.conn.Do(ctx, func(client *websocket.Client) error {
+		var err error
+		resp, err = client.GetAccountInfo(&account.InfoRequest{
+			Account:     address,
+			LedgerIndex: common.Validated,
+		})
+		return err
+	})
+	if errors.Is(err, ErrAccountNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint64(resp.AccountData.Balance), true, nil
+}
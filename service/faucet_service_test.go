@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequestFunding tests reading the funded amount and payment hash from a faucet response
+func TestRequestFunding(t *testing.T) {
+	var destination string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		destination = req["destination"]
+		if destination == "rBusy" {
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"account":{"classicAddress":"` + destination + `"},"amount":100,"transactionHash":"ABCD"}`))
+	}))
+	defer server.Close()
+
+	resp, err := requestFunding(context.Background(), server.URL, "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	require.NoError(t, err)
+	assert.Equal(t, "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe", destination)
+	assert.Equal(t, json.Number("100"), resp.Amount)
+	assert.Equal(t, "ABCD", resp.TransactionHash)
+
+	_, err = requestFunding(context.Background(), server.URL, "rBusy")
+	assert.Error(t, err)
+
+	// Faucet services report the amount in XRP
+	amount, txHash, err := NewHTTPFaucet(server.URL).Fund(context.Background(), "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100000000), amount)
+	assert.Equal(t, "ABCD", txHash)
+}
+
+// TestNewFaucetProvider tests selecting the faucet provider from configuration
+func TestNewFaucetProvider(t *testing.T) {
+	s := &XRPLService{}
+
+	provider, err := newFaucetProvider(s, &config.Config{Faucet: config.FaucetTestnet})
+	require.NoError(t, err)
+	assert.Equal(t, TestnetFaucetURL, provider.(*HTTPFaucet).URL)
+
+	provider, err = newFaucetProvider(s, &config.Config{Faucet: "http://127.0.0.1:8000/accounts"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:8000/accounts", provider.(*HTTPFaucet).URL)
+
+	provider, err = newFaucetProvider(s, &config.Config{Faucet: config.FaucetGenesis, GenesisSecret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb", FaucetAmountDrops: 1000})
+	require.NoError(t, err)
+	funder := provider.(*GenesisFunder)
+	assert.Equal(t, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", funder.funder.Address().String())
+	assert.Equal(t, uint64(1000), funder.amountDrops)
+
+	_, err = newFaucetProvider(s, &config.Config{Faucet: config.FaucetGenesis, GenesisSecret: "invalid"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+// FeePolicy controls the transaction cost of every transaction the service submits
+type FeePolicy struct {
+	FixedDrops uint64  // Fixed fee in drops, zero to follow the open ledger fee
+	Multiplier float64 // Multiplier applied to the open ledger fee when no fixed fee is set
+	MaxDrops   uint64  // Transactions costing more are refused with ErrFeeTooHigh, zero means no limit
+}
+
+// Pick the fee in drops for a transaction, also returning the open ledger fee when it was queried
+func (p FeePolicy) fee(client *websocket.Client) (uint64, uint64, error) {
+	if p.FixedDrops > 0 {
+		return p.FixedDrops, 0, nil
+	}
+
+	resp, err := client.GetFee(&server.FeeRequest{})
+	if err != nil {
+		return 0, 0, err
+	}
+	openLedgerFee := resp.Drops.OpenLedgerFee.Uint64()
+
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	fee := uint64(math.Ceil(float64(openLedgerFee) * multiplier))
+
+	// Never pay less than the reference transaction cost
+	if baseFee := resp.Drops.BaseFee.Uint64(); fee < baseFee {
+		fee = baseFee
+	}
+	return fee, openLedgerFee, nil
+}
+
+// Refuse fees above the cap
+func (p FeePolicy) check(fee string) error {
+	drops, err := strconv.ParseUint(fee, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid fee %q: %w", fee, err)
+	}
+	if p.MaxDrops > 0 && drops > p.MaxDrops {
+		return fmt.Errorf("%w: %d drops exceeds the maximum of %d drops", ErrFeeTooHigh, drops, p.MaxDrops)
+	}
+	return nil
+}
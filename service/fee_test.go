@@ -0,0 +1,23 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeePolicy tests fixed fees and the fee cap
+func TestFeePolicy(t *testing.T) {
+	policy := FeePolicy{FixedDrops: 15, MaxDrops: 100}
+
+	fee, openLedgerFee, err := policy.fee(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(15), fee)
+	assert.Zero(t, openLedgerFee)
+
+	assert.NoError(t, policy.check("100"))
+	assert.ErrorIs(t, policy.check("101"), ErrFeeTooHigh)
+	assert.Error(t, policy.check("abc"))
+	assert.NoError(t, FeePolicy{}.check("5000000"))
+}
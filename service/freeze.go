@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// AccountSet flags controlling freezes of all tokens of an issuer
+const (
+	asfNoFreeze     uint32 = 6
+	asfGlobalFreeze uint32 = 7
+)
+
+// FreezeState describes the issuer-wide freeze settings of an account
+type FreezeState struct {
+	Account      types.Address `json:"account"`
+	GlobalFreeze bool          `json:"globalFreeze"` // Whether all tokens issued by the account are frozen
+	NoFreeze     bool          `json:"noFreeze"`     // Whether the account gave up freezing trust lines and lifting a global freeze
+}
+
+// SetGlobalFreeze calls SetGlobalFreezeContext with a background context
+func (s *XRPLService) SetGlobalFreeze(issuer Signer, enabled bool) (*TxResult, error) {
+	return s.SetGlobalFreezeContext(context.Background(), issuer, enabled)
+}
+
+// SetGlobalFreezeContext freezes or unfreezes all tokens issued by the signer's account. While frozen, holders can only
+// send tokens to and receive them from the issuer. An issuer with NoFreeze set can no longer lift a global freeze.
+func (s *XRPLService) SetGlobalFreezeContext(ctx context.Context, issuer Signer, enabled bool) (*TxResult, error) {
+	accountSet := &transaction.AccountSet{
+		BaseTx: transaction.BaseTx{
+			Account: issuer.Address(),
+		},
+	}
+	if enabled {
+		accountSet.SetAsfGlobalFreeze()
+	} else {
+		accountSet.ClearAsfGlobalFreeze()
+	}
+
+	// Autofill, sign, submit and track transaction to its final outcome
+	result, err := s.SubmitTransactionContext(ctx, issuer, accountSet.Flatten())
+	if err != nil {
+		return result, fmt.Errorf("unable to update global freeze: %w", err)
+	}
+	return result, nil
+}
+
+// SetNoFreeze calls SetNoFreezeContext with a background context
+func (s *XRPLService) SetNoFreeze(issuer Signer) (*TxResult, error) {
+	return s.SetNoFreezeContext(context.Background(), issuer)
+}
+
+// SetNoFreezeContext permanently gives up the signer's ability to freeze trust lines of its tokens and to lift a global freeze.
+// The setting cannot be cleared once the transaction is validated.
+func (s *XRPLService) SetNoFreezeContext(ctx context.Context, issuer Signer) (*TxResult, error) {
+	accountSet := &transaction.AccountSet{
+		BaseTx: transaction.BaseTx{
+			Account: issuer.Address(),
+		},
+	}
+	accountSet.SetAsfNoFreeze()
+
+	// Autofill, sign, submit and track transaction to its final outcome
+	result, err := s.SubmitTransactionContext(ctx, issuer, accountSet.Flatten())
+	if err != nil {
+		return result, fmt.Errorf("unable to set no freeze: %w", err)
+	}
+	return result, nil
+}
+
+// GetFreezeState calls GetFreezeStateContext with a background context
+func (s *XRPLService) GetFreezeState(issuer types.Address) (*FreezeState, error) {
+	return s.GetFreezeStateContext(context.Background(), issuer)
+}
+
+// GetFreezeStateContext queries the global freeze and NoFreeze settings of an issuer
+func (s *XRPLService) GetFreezeStateContext(ctx context.Context, issuer types.Address) (*FreezeState, error) {
+	account, err := s.ledgerAccount(ctx, issuer.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get account info: %w", err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, issuer)
+	}
+
+	return &FreezeState{
+		Account:      issuer,
+		GlobalFreeze: account.Flags&lsfGlobalFreeze != 0,
+		NoFreeze:     account.Flags&lsfNoFreeze != 0,
+	}, nil
+}
+
+// Refuse a token payment between holders while the issuer has frozen all its tokens,
+// payments to and from the issuer itself are still allowed
+func (s *XRPLService) checkGlobalFreeze(ctx context.Context, sender, receiver, issuer types.Address) error {
+	if sender == issuer || receiver == issuer {
+		return nil
+	}
+	account, err := s.ledgerAccount(ctx, issuer.String())
+	if err != nil {
+		return fmt.Errorf("unable to get account info: %w", err)
+	}
+	if account == nil {
+		return fmt.Errorf("%w: %s", ErrAccountNotFound, issuer)
+	}
+	return globalFreeze(account)
+}
+
+// Check that an issuer has not frozen all its tokens
+func globalFreeze(issuer *ledger.AccountRoot) error {
+	if issuer.Flags&lsfGlobalFreeze != 0 {
+		return fmt.Errorf("%w: issuer %s has frozen all its tokens", ErrGlobalFreeze, issuer.Account)
+	}
+	return nil
+}
+
+// DeepFreezeTrustLine calls DeepFreezeTrustLineContext with a background context
+func (s *XRPLService) DeepFreezeTrustLine(signer Signer, trustlineAddress types.Address, tokenName string) (*TxResult, error) {
+	return s.DeepFreezeTrustLineContext(context.Background(), signer, trustlineAddress, tokenName)
+}
+
+// DeepFreezeTrustLineContext deep freezes a trust line the signer has already frozen, so that the counterparty can
+// neither send nor receive the token
+func (s *XRPLService) DeepFreezeTrustLineContext(ctx context.Context, signer Signer, trustlineAddress types.Address, tokenName string) (*TxResult, error) {
+	result, err := s.updateTrustLineFlags(ctx, signer, trustlineAddress, tokenName, func(line *TrustLine, trustSet *transaction.TrustSet) error {
+		if !line.Freeze {
+			return fmt.Errorf("%w: freeze the %s trust line with %s before deep freezing it", ErrLineNotFrozen, tokenName, trustlineAddress)
+		}
+		trustSet.SetSetDeepFreezeFlag()
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("unable to deep freeze trust line: %w", err)
+	}
+	return result, nil
+}
+
+// ClearDeepFreezeTrustLine calls ClearDeepFreezeTrustLineContext with a background context
+func (s *XRPLService) ClearDeepFreezeTrustLine(signer Signer, trustlineAddress types.Address, tokenName string) (*TxResult, error) {
+	return s.ClearDeepFreezeTrustLineContext(context.Background(), signer, trustlineAddress, tokenName)
+}
+
+// ClearDeepFreezeTrustLineContext lifts the deep freeze of a trust line, which stays frozen until it is unfrozen
+func (s *XRPLService) ClearDeepFreezeTrustLineContext(ctx context.Context, signer Signer, trustlineAddress types.Address, tokenName string) (*TxResult, error) {
+	result, err := s.updateTrustLineFlags(ctx, signer, trustlineAddress, tokenName, func(line *TrustLine, trustSet *transaction.TrustSet) error {
+		trustSet.SetClearDeepFreezeFlag()
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("unable to clear deep freeze: %w", err)
+	}
+	return result, nil
+}
+
+// FreezeTrustLines calls FreezeTrustLinesContext with a background context
+func (s *XRPLService) FreezeTrustLines(signer Signer, trustlineAddresses []types.Address, tokenName string, freeze bool) []TrustLineResult {
+	return s.FreezeTrustLinesContext(context.Background(), signer, trustlineAddresses, tokenName, freeze)
+}
+
+// FreezeTrustLinesContext freezes, or unfreezes if freeze is false, the signer's trust lines for a token with many
+// counterparties. Transactions are pipelined like TransferTokens and a failed line does not stop the others.
+// Results are in the order of trustlineAddresses.
+func (s *XRPLService) FreezeTrustLinesContext(ctx context.Context, signer Signer, trustlineAddresses []types.Address, tokenName string, freeze bool) []TrustLineResult {
+	return s.updateTrustLines(ctx, trustlineAddresses, func(address types.Address) (*TxResult, error) {
+		if freeze {
+			return s.FreezeTrustLineContext(ctx, signer, address, tokenName)
+		}
+		return s.UnfreezeTrustLineContext(ctx, signer, address, tokenName)
+	})
+}
+
+// Change the flags of the signer's side of an existing trust line. A TrustSet always sets the signer's limit,
+// so the current limit is read first and sent unchanged; the flags are set by setFlags, which may refuse the change.
+func (s *XRPLService) updateTrustLineFlags(ctx context.Context, signer Signer, trustlineAddress types.Address, tokenName string,
+	setFlags func(line *TrustLine, trustSet *transaction.TrustSet) error) (*TxResult, error) {
+	line, err := s.ledgerTrustLine(ctx, signer.Address().String(), trustlineAddress.String(), tokenName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get trust line: %w", err)
+	}
+	if line == nil {
+		return nil, fmt.Errorf("%w: %s has no %s trust line with %s", ErrNoTrustLine, signer.Address(), tokenName, trustlineAddress)
+	}
+
+	trustSet := &transaction.TrustSet{
+		BaseTx: transaction.BaseTx{
+			Account: signer.Address(),
+		},
+		LimitAmount: types.IssuedCurrencyAmount{
+			Currency: tokenName,
+			Issuer:   trustlineAddress,
+			Value:    line.Limit,
+		},
+	}
+	if err := setFlags(line, trustSet); err != nil {
+		return nil, err
+	}
+
+	// Autofill, sign, submit and track transaction to its final outcome
+	return s.SubmitTransactionContext(ctx, signer, trustSet.Flatten())
+}
+
+// Check that a deep freeze is only set on a frozen line and is lifted before the line is unfrozen
+func deepFreezeChecks(line *TrustLine, flags uint32) []PreflightCheck {
+	var checks []PreflightCheck
+	if flags&tfSetDeepFreeze != 0 {
+		frozen := line.Freeze || flags&tfSetFreeze != 0
+		checks = append(checks, PreflightCheck{Name: "frozen before deep freeze", Passed: frozen,
+			Detail: fmt.Sprintf("%s trust line with %s frozen: %t", line.Currency, line.Account, frozen)})
+	}
+	if flags&tfClearFreeze != 0 && line.DeepFreeze {
+		checks = append(checks, PreflightCheck{Name: "deep freeze cleared", Passed: flags&tfClearDeepFreeze != 0,
+			Detail: fmt.Sprintf("%s trust line with %s is deep frozen, clear the deep freeze before unfreezing it", line.Currency, line.Account)})
+	}
+	return checks
+}
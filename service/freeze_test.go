@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheckGlobalFreeze tests that payments to and from the issuer skip the global freeze lookup
+func TestCheckGlobalFreeze(t *testing.T) {
+	s := &XRPLService{}
+
+	assert.NoError(t, s.checkGlobalFreeze(context.Background(), "rIssuer", "rHolder", "rIssuer"))
+	assert.NoError(t, s.checkGlobalFreeze(context.Background(), "rHolder", "rIssuer", "rIssuer"))
+}
+
+// TestGlobalFreeze tests that payments between holders are refused while the issuer has frozen all its tokens
+func TestGlobalFreeze(t *testing.T) {
+	issuer := &ledger.AccountRoot{Account: "rIssuer"}
+	assert.NoError(t, globalFreeze(issuer))
+
+	issuer.Flags = lsfNoFreeze
+	assert.NoError(t, globalFreeze(issuer))
+
+	issuer.Flags = lsfGlobalFreeze
+	assert.ErrorIs(t, globalFreeze(issuer), ErrGlobalFreeze)
+}
+
+// TestDeepFreezeChecks tests that deep freezes need a frozen line and are lifted before unfreezing
+func TestDeepFreezeChecks(t *testing.T) {
+	unfrozen := &TrustLine{Account: "rHolder", Currency: "USD"}
+	frozen := &TrustLine{Account: "rHolder", Currency: "USD", Freeze: true}
+	deepFrozen := &TrustLine{Account: "rHolder", Currency: "USD", Freeze: true, DeepFreeze: true}
+
+	assert.False(t, deepFreezeChecks(unfrozen, tfSetDeepFreeze)[0].Passed)
+	assert.True(t, deepFreezeChecks(unfrozen, tfSetFreeze|tfSetDeepFreeze)[0].Passed)
+	assert.True(t, deepFreezeChecks(frozen, tfSetDeepFreeze)[0].Passed)
+
+	assert.Empty(t, deepFreezeChecks(frozen, tfClearFreeze))
+	assert.False(t, deepFreezeChecks(deepFrozen, tfClearFreeze)[0].Passed)
+	assert.True(t, deepFreezeChecks(deepFrozen, tfClearFreeze|tfClearDeepFreeze)[0].Passed)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Peersyst/xrpl-go/keypairs"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultDerivationPath is the BIP44 path of the first XRP Ledger account of a mnemonic
+const DefaultDerivationPath = "m/44'/144'/0'/0/0"
+
+// Entropy of generated mnemonics, 256 bits give 24 words
+const mnemonicEntropyBits = 256
+
+// NewMnemonic generates a random 24 word BIP39 mnemonic
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropyBits)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate entropy: %w", err)
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// WalletFromMnemonic derives the secp256k1 wallet at a BIP44 path of a BIP39 mnemonic, the same way other
+// XRP Ledger wallets do. An empty path selects DefaultDerivationPath. The wallet has no seed.
+func WalletFromMnemonic(mnemonic, path string) (*wallet.Wallet, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("invalid mnemonic")
+	}
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := bip32.NewMasterKey(bip39.NewSeed(mnemonic, ""))
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive master key: %w", err)
+	}
+	for _, index := range indexes {
+		if key, err = key.NewChildKey(index); err != nil {
+			return nil, fmt.Errorf("unable to derive key at %s: %w", path, err)
+		}
+	}
+
+	publicKey := strings.ToUpper(hex.EncodeToString(key.PublicKey().Key))
+	address, err := keypairs.DeriveClassicAddress(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive address: %w", err)
+	}
+	// secp256k1 private keys are prefixed with 00 to tell them apart from ed25519 keys
+	return &wallet.Wallet{
+		PublicKey:      publicKey,
+		PrivateKey:     "00" + strings.ToUpper(hex.EncodeToString(key.Key)),
+		ClassicAddress: types.Address(address),
+	}, nil
+}
+
+// Parse a BIP32 path such as m/44'/144'/0'/0/0 into child indexes, hardened ones marked with ' or h
+func parseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" || len(parts) < 2 {
+		return nil, fmt.Errorf("invalid derivation path %q, expected a path like %s", path, DefaultDerivationPath)
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		index, err := strconv.ParseUint(strings.TrimRight(part, "'h"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: bad index %q", path, part)
+		}
+		child := uint32(index)
+		if hardened {
+			child += bip32.FirstHardenedChild
+		}
+		indexes = append(indexes, child)
+	}
+	return indexes, nil
+}
+
+// Derivation path offset accounts after path, counting up its last index
+func nextDerivationPath(path string, offset int) (string, error) {
+	if _, err := parseDerivationPath(path); err != nil {
+		return "", err
+	}
+	i := strings.LastIndex(path, "/")
+	last := path[i+1:]
+	suffix := strings.TrimLeft(last, "0123456789")
+	index, _ := strconv.Atoi(strings.TrimSuffix(last, suffix))
+	next := index + offset
+	if next >= int(bip32.FirstHardenedChild) {
+		return "", fmt.Errorf("derivation path %q has no room for %d more accounts", path, offset)
+	}
+	return path[:i+1] + strconv.Itoa(next) + suffix, nil
+}
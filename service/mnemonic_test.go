@@ -0,0 +1,82 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWalletFromMnemonic tests deriving wallets from a mnemonic at BIP44 paths
+func TestWalletFromMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic()
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+
+	// The default path matches the derivation of the XRPL library
+	expected, err := wallet.FromMnemonic(mnemonic)
+	require.NoError(t, err)
+	derived, err := WalletFromMnemonic(mnemonic, "")
+	require.NoError(t, err)
+	assert.Equal(t, expected.ClassicAddress, derived.ClassicAddress)
+	assert.Equal(t, expected.PrivateKey, derived.PrivateKey)
+
+	_, _, err = derived.Sign(transaction.FlatTransaction{
+		"TransactionType": "AccountSet",
+		"Account":         derived.ClassicAddress.String(),
+		"Fee":             "12",
+		"Sequence":        uint32(1),
+	})
+	assert.NoError(t, err)
+
+	other, err := WalletFromMnemonic(mnemonic, "m/44'/144'/0'/0/1")
+	require.NoError(t, err)
+	assert.NotEqual(t, derived.ClassicAddress, other.ClassicAddress)
+
+	_, err = WalletFromMnemonic("not a mnemonic", "")
+	assert.Error(t, err)
+	_, err = WalletFromMnemonic(mnemonic, "44'/144'")
+	assert.Error(t, err)
+	_, err = WalletFromMnemonic(mnemonic, "m/44'/x")
+	assert.Error(t, err)
+}
+
+// TestCreateAccountWithOptions tests key algorithms and deriving several accounts from one mnemonic
+func TestCreateAccountWithOptions(t *testing.T) {
+	s := &XRPLService{}
+
+	accounts, err := s.CreateAccountWithOptions(&CreateAccountOptions{Algorithm: AlgorithmSECP256K1})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.False(t, strings.HasPrefix(accounts[0].Wallet.PublicKey, "ED"))
+	assert.NotEmpty(t, accounts[0].Wallet.Seed)
+
+	accounts, err = s.CreateAccountWithOptions(nil)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(accounts[0].Wallet.PublicKey, "ED"))
+
+	accounts, err = s.CreateAccountWithOptions(&CreateAccountOptions{Mnemonic: true, Count: 3})
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	assert.Equal(t, "m/44'/144'/0'/0/2", accounts[2].DerivationPath)
+	assert.NotEqual(t, accounts[0].Wallet.ClassicAddress, accounts[1].Wallet.ClassicAddress)
+
+	restored, err := s.CreateAccountWithOptions(&CreateAccountOptions{
+		RestoreMnemonic: accounts[0].Mnemonic,
+		DerivationPath:  "m/44'/144'/0'/0/1",
+		Count:           2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, accounts[1].Wallet.ClassicAddress, restored[0].Wallet.ClassicAddress)
+	assert.Equal(t, accounts[2].Wallet.ClassicAddress, restored[1].Wallet.ClassicAddress)
+
+	_, err = s.CreateAccountWithOptions(&CreateAccountOptions{Count: 2})
+	assert.Error(t, err)
+	_, err = s.CreateAccountWithOptions(&CreateAccountOptions{Mnemonic: true, Algorithm: AlgorithmED25519})
+	assert.Error(t, err)
+	_, err = s.CreateAccountWithOptions(&CreateAccountOptions{Algorithm: "rsa"})
+	assert.Error(t, err)
+}
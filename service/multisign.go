@@ -0,0 +1,291 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	addresscodec "github.com/Peersyst/xrpl-go/address-codec"
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/hash"
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+// SignerEntry is a key allowed to sign for an account as part of its signer list
+type SignerEntry struct {
+	Account types.Address `json:"account"` // Address of the signing key, it does not need to be a funded account
+	Weight  uint16        `json:"weight"`  // Weight the signature contributes towards the quorum
+}
+
+// SignerList is the set of keys that together control an account
+type SignerList struct {
+	Quorum  uint32        `json:"quorum"`  // Total weight of signatures required, zero removes the signer list
+	Signers []SignerEntry `json:"signers"` // Signers with their weights
+}
+
+// Validate checks the signer list against the limits of the ledger
+func (l *SignerList) Validate(owner types.Address) error {
+	if l.Quorum == 0 {
+		if len(l.Signers) > 0 {
+			return fmt.Errorf("signers must be empty when removing the signer list with quorum 0")
+		}
+		return nil
+	}
+	if len(l.Signers) < transaction.MinSigners || len(l.Signers) > transaction.MaxSigners {
+		return fmt.Errorf("signer list must have between %d and %d signers", transaction.MinSigners, transaction.MaxSigners)
+	}
+
+	total := uint32(0)
+	seen := make(map[types.Address]bool)
+	for _, signer := range l.Signers {
+		if !addresscodec.IsValidClassicAddress(signer.Account.String()) {
+			return fmt.Errorf("invalid signer address %q", signer.Account)
+		}
+		if signer.Account == owner {
+			return fmt.Errorf("account %s cannot be in its own signer list", owner)
+		}
+		if seen[signer.Account] {
+			return fmt.Errorf("signer %s is listed more than once", signer.Account)
+		}
+		if signer.Weight == 0 {
+			return fmt.Errorf("signer %s must have a weight", signer.Account)
+		}
+		seen[signer.Account] = true
+		total += uint32(signer.Weight)
+	}
+	if l.Quorum > total {
+		return fmt.Errorf("quorum %d exceeds the total signer weight %d", l.Quorum, total)
+	}
+	return nil
+}
+
+// SetSignerList calls SetSignerListContext with a background context
+func (s *XRPLService) SetSignerList(signer Signer, list *SignerList) (*TxResult, error) {
+	return s.SetSignerListContext(context.Background(), signer, list)
+}
+
+// SetSignerListContext installs or replaces the signer list of the signer's account, a quorum of zero removes it
+func (s *XRPLService) SetSignerListContext(ctx context.Context, signer Signer, list *SignerList) (*TxResult, error) {
+	if err := list.Validate(signer.Address()); err != nil {
+		return nil, err
+	}
+
+	signerListSet := &transaction.SignerListSet{
+		BaseTx: transaction.BaseTx{
+			Account: signer.Address(),
+		},
+		SignerQuorum: list.Quorum,
+	}
+	for _, signer := range list.Signers {
+		signerListSet.SignerEntries = append(signerListSet.SignerEntries, ledger.SignerEntryWrapper{
+			SignerEntry: ledger.SignerEntry{
+				Account:      signer.Account,
+				SignerWeight: signer.Weight,
+			},
+		})
+	}
+
+	// Autofill, sign, submit and track transaction to its final outcome
+	result, err := s.SubmitTransactionContext(ctx, signer, signerListSet.Flatten())
+	if err != nil {
+		return result, fmt.Errorf("unable to set signer list: %w", err)
+	}
+	return result, nil
+}
+
+// SignerList calls SignerListContext with a background context
+func (s *XRPLService) SignerList(address types.Address) (*SignerList, error) {
+	return s.SignerListContext(context.Background(), address)
+}
+
+// SignerListContext returns the signer list of an account, nil if it has none
+func (s *XRPLService) SignerListContext(ctx context.Context, address types.Address) (*SignerList, error) {
+	var resp *account.InfoResponse
+	_, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		var err error
+		resp, err = client.GetAccountInfo(&account.InfoRequest{
+			Account:     address,
+			LedgerIndex: common.Validated,
+			SignerLists: true,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get signer list: %w", err)
+	}
+	if len(resp.SignerLists) == 0 {
+		return nil, nil
+	}
+
+	list := &SignerList{Quorum: resp.SignerLists[0].SignerQuorum}
+	for _, entry := range resp.SignerLists[0].SignerEntries {
+		list.Signers = append(list.Signers, SignerEntry{
+			Account: entry.SignerEntry.Account,
+			Weight:  entry.SignerEntry.SignerWeight,
+		})
+	}
+	return list, nil
+}
+
+// WithMultisignPrepare returns a prepare-only service whose transactions are prepared for multisigning by
+// the given number of signers: the fee covers every signature and the transaction carries no signing key.
+// Each signer signs with MultisignOffline, CombineSignatures merges the signatures for submission.
+func (s *XRPLService) WithMultisignPrepare(signers uint32) *XRPLService {
+	prepareOnly := s.WithPrepareOnly()
+	prepareOnly.multisigners = signers
+	return prepareOnly
+}
+
+// MultisignOffline signs a transaction prepared for multisigning as one of the account's signers,
+// without access to the XRP Ledger. The result holds only this signer's signature.
+func MultisignOffline(ctx context.Context, signer Signer, txBlob string) (*EncodedTransaction, error) {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+	if _, signed := tx["TxnSignature"]; signed {
+		return nil, fmt.Errorf("transaction is already signed with a single key")
+	}
+
+	// Signatures are collected separately and merged by CombineSignatures
+	delete(tx, "Signers")
+	signedBlob, _, err := signer.Multisign(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign transaction: %w", err)
+	}
+
+	return &EncodedTransaction{
+		Transaction: tx,
+		TxBlob:      signedBlob,
+	}, nil
+}
+
+// CombineSignatures merges the signatures of the same transaction signed by different signers
+// into a multisigned transaction ready for submission
+func CombineSignatures(txBlobs ...string) (*EncodedTransaction, error) {
+	if len(txBlobs) == 0 {
+		return nil, fmt.Errorf("no signed transactions to combine")
+	}
+
+	var combined transaction.FlatTransaction
+	var signingData string
+	signers := make(map[string]any)
+	for i, txBlob := range txBlobs {
+		tx, err := binarycodec.Decode(txBlob)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode transaction %d: %w", i+1, err)
+		}
+		txSigners, _ := tx["Signers"].([]any)
+		if len(txSigners) == 0 {
+			return nil, fmt.Errorf("transaction %d has no multisignatures", i+1)
+		}
+
+		// Every signer must have signed exactly the same transaction
+		delete(tx, "Signers")
+		data, err := binarycodec.EncodeForSigning(tx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode transaction %d: %w", i+1, err)
+		}
+		if combined == nil {
+			combined, signingData = tx, data
+		} else if data != signingData {
+			return nil, fmt.Errorf("transaction %d differs from transaction 1, signatures can only be combined for the same transaction", i+1)
+		}
+
+		for _, txSigner := range txSigners {
+			signers[signerAccount(txSigner)] = txSigner
+		}
+	}
+
+	sorted, err := sortSigners(signers)
+	if err != nil {
+		return nil, err
+	}
+	combined["Signers"] = sorted
+
+	blob, err := binarycodec.Encode(combined)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode transaction: %w", err)
+	}
+	txHash, err := hash.SignTxBlob(blob)
+	if err != nil {
+		return nil, fmt.Errorf("unable to hash transaction: %w", err)
+	}
+
+	return &EncodedTransaction{
+		Transaction: combined,
+		TxBlob:      blob,
+		Hash:        txHash,
+	}, nil
+}
+
+// Account of a Signers array member
+func signerAccount(txSigner any) string {
+	wrapper, _ := txSigner.(map[string]any)
+	signer, _ := wrapper["Signer"].(map[string]any)
+	address, _ := signer["Account"].(string)
+	return address
+}
+
+// The ledger requires signers sorted by numeric account ID, which differs from the order of their addresses
+func sortSigners(signers map[string]any) ([]any, error) {
+	type signerID struct {
+		id     []byte
+		signer any
+	}
+
+	ids := make([]signerID, 0, len(signers))
+	for address, signer := range signers {
+		_, id, err := addresscodec.DecodeClassicAddressToAccountID(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer address %q: %w", address, err)
+		}
+		ids = append(ids, signerID{id: id, signer: signer})
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i].id, ids[j].id) < 0
+	})
+
+	sorted := make([]any, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, id.signer)
+	}
+	return sorted, nil
+}
+
+// Check that the signatures of a multisigned transaction meet the quorum of the account's signer list
+func (s *XRPLService) quorumCheck(ctx context.Context, address string, txSigners []any) (PreflightCheck, error) {
+	list, err := s.SignerListContext(ctx, types.Address(address))
+	if err != nil {
+		return PreflightCheck{}, err
+	}
+	if list == nil {
+		return PreflightCheck{Name: "signer quorum", Passed: false, Detail: fmt.Sprintf("account %s has no signer list", address)}, nil
+	}
+
+	weights := make(map[string]uint32)
+	for _, signer := range list.Signers {
+		weights[signer.Account.String()] = uint32(signer.Weight)
+	}
+	total := uint32(0)
+	var unknown []string
+	for _, txSigner := range txSigners {
+		weight, ok := weights[signerAccount(txSigner)]
+		if !ok {
+			unknown = append(unknown, signerAccount(txSigner))
+		}
+		total += weight
+	}
+
+	detail := fmt.Sprintf("signature weight %d, quorum %d", total, list.Quorum)
+	if len(unknown) > 0 {
+		detail += fmt.Sprintf(", not in the signer list: %v", unknown)
+	}
+	return PreflightCheck{Name: "signer quorum", Passed: total >= list.Quorum && len(unknown) == 0, Detail: detail}, nil
+}
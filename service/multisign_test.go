@@ -0,0 +1,82 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	addresscodec "github.com/Peersyst/xrpl-go/address-codec"
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/pkg/crypto"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignerListValidate tests signer list limits
+func TestSignerListValidate(t *testing.T) {
+	owner := types.Address("rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn")
+	signers := []SignerEntry{
+		{Account: "rsA2LpzuawewSBQXkiju3YQTMzW13pAAdW", Weight: 2},
+		{Account: "rUpy3eEg8rqjqfUoLeBnZkscbKbFsKXC3v", Weight: 1},
+	}
+
+	assert.NoError(t, (&SignerList{Quorum: 3, Signers: signers}).Validate(owner))
+	assert.NoError(t, (&SignerList{}).Validate(owner))
+	assert.Error(t, (&SignerList{Quorum: 4, Signers: signers}).Validate(owner))
+	assert.Error(t, (&SignerList{Quorum: 0, Signers: signers}).Validate(owner))
+	assert.Error(t, (&SignerList{Quorum: 1}).Validate(owner))
+	assert.Error(t, (&SignerList{Quorum: 1, Signers: append(signers, signers[0])}).Validate(owner))
+	assert.Error(t, (&SignerList{Quorum: 1, Signers: []SignerEntry{{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", Weight: 1}}}).Validate(owner))
+}
+
+// TestCombineSignatures tests collecting signatures separately and merging them in account ID order
+func TestCombineSignatures(t *testing.T) {
+	tx := transaction.FlatTransaction{
+		"TransactionType":    "AccountSet",
+		"Account":            "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+		"Fee":                "36",
+		"Sequence":           uint32(7),
+		"LastLedgerSequence": uint32(1000),
+		"SigningPubKey":      "",
+	}
+	prepared, err := binarycodec.Encode(tx)
+	require.NoError(t, err)
+
+	var partials []string
+	for i := 0; i < 3; i++ {
+		signer, err := wallet.New(crypto.ED25519())
+		require.NoError(t, err)
+		partial, err := MultisignOffline(context.Background(), NewWalletSigner(&signer), prepared)
+		require.NoError(t, err)
+		partials = append(partials, partial.TxBlob)
+	}
+
+	combined, err := CombineSignatures(append(partials, partials[0])...)
+	require.NoError(t, err)
+	assert.Len(t, combined.Hash, 64)
+
+	signers := combined.Transaction["Signers"].([]any)
+	require.Len(t, signers, 3)
+	for i := 1; i < len(signers); i++ {
+		_, previous, _ := addresscodec.DecodeClassicAddressToAccountID(signerAccount(signers[i-1]))
+		_, current, _ := addresscodec.DecodeClassicAddressToAccountID(signerAccount(signers[i]))
+		assert.Negative(t, bytes.Compare(previous, current))
+	}
+
+	// Signatures of a different transaction cannot be combined
+	tx["Sequence"] = uint32(8)
+	other, err := binarycodec.Encode(tx)
+	require.NoError(t, err)
+	signer, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+	otherPartial, err := MultisignOffline(context.Background(), NewWalletSigner(&signer), other)
+	require.NoError(t, err)
+	_, err = CombineSignatures(partials[0], otherPartial.TxBlob)
+	assert.Error(t, err)
+
+	_, err = CombineSignatures(prepared)
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/config"
+)
+
+// Network returns the network profile the service is connected to
+func (s *XRPLService) Network() config.Network {
+	return s.network
+}
+
+// WithMainnetConfirmed returns a service allowed to submit transactions to mainnet.
+// Without it every write operation on mainnet fails with ErrMainnetNotConfirmed, dry runs and
+// prepared transactions are not affected. It shares the connection of s, close s when done.
+func (s *XRPLService) WithMainnetConfirmed() *XRPLService {
+	confirmed := *s
+	confirmed.mainnetConfirmed = true
+	return &confirmed
+}
+
+// Refuse to submit a transaction to mainnet unless the caller confirmed it
+func (s *XRPLService) checkWriteAllowed() error {
+	if s.network.Mainnet && !s.mainnetConfirmed {
+		return fmt.Errorf("%w: transactions on %s move real funds, confirm them explicitly", ErrMainnetNotConfirmed, s.network)
+	}
+	return nil
+}
+
+// Refuse to submit to a configured node outside the public nodes of the network profile when it reports
+// the network ID of another network, or network ID zero, which mainnet shares with standalone ledgers,
+// without confirmation unless the profile is a standalone ledger. Must be called within conn.Do, with the client the transaction is submitted to.
+func (s *XRPLService) checkNodeNetwork(client *websocket.Client) error {
+	if s.nodeNetworkIDs == nil {
+		return nil
+	}
+	networkID, ok := s.nodeNetworkIDs.Load(client)
+	if !ok {
+		resp, err := client.GetServerInfo(&server.InfoRequest{})
+		if err != nil {
+			return fmt.Errorf("unable to get node network: %w", err)
+		}
+		networkID = uint32(resp.Info.NetworkID)
+		s.nodeNetworkIDs.Store(client, networkID)
+	}
+	return s.checkNetworkID(networkID.(uint32))
+}
+
+// Check the network ID reported by a node that is not a public node of the network profile
+func (s *XRPLService) checkNetworkID(networkID uint32) error {
+	if s.network.Name != "" && networkID != s.network.NetworkID {
+		return fmt.Errorf("node reports network ID %d, %s expects %d", networkID, s.network, s.network.NetworkID)
+	}
+	// A standalone profile expects network ID zero, only mainnet and unknown networks need confirmation
+	if networkID == 0 && (s.network.Mainnet || s.network.Name == "") && !s.mainnetConfirmed {
+		return fmt.Errorf("%w: the node reports network ID 0 like mainnet and is not a public node of the network profile, confirm transactions explicitly",
+			ErrMainnetNotConfirmed)
+	}
+	return nil
+}
+
+// Query server_info of the active node
+func (s *XRPLService) serverInfo(ctx context.Context) (*server.InfoResponse, error) {
+	var resp *server.InfoResponse
+	_, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		var err error
+		resp, err = client.GetServerInfo(&server.InfoRequest{})
+		return err
+	})
+	return resp, err
+}
+
+// CheckNetwork calls CheckNetworkContext with a background context
+func (s *XRPLService) CheckNetwork() ([]PreflightCheck, error) {
+	return s.CheckNetworkContext(context.Background())
+}
+
+// CheckNetworkContext compares the network ID and reserves reported by the active node with the network
+// profile, so that a node of another network or unexpected reserves are noticed before submitting
+func (s *XRPLService) CheckNetworkContext(ctx context.Context) ([]PreflightCheck, error) {
+	resp, err := s.serverInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.network.Name == "" {
+		return nil, nil
+	}
+
+	info := resp.Info
+	checks := []PreflightCheck{{
+		Name:   "Network ID",
+		Passed: uint32(info.NetworkID) == s.network.NetworkID,
+		Detail: fmt.Sprintf("node reports %d, %s expects %d", info.NetworkID, s.network, s.network.NetworkID),
+	}}
+
+	if s.network.ReserveBaseDrops > 0 {
+		base := uint64(float64(info.ValidatedLedger.ReserveBaseXRP)*1e6 + 0.5)
+		increment := uint64(float64(info.ValidatedLedger.ReserveIncXRP)*1e6 + 0.5)
+		checks = append(checks, PreflightCheck{
+			Name:   "Reserves",
+			Passed: base == s.network.ReserveBaseDrops && increment == s.network.ReserveIncrementDrops,
+			Detail: fmt.Sprintf("node reports %s base and %s per object, %s expects %s and %s",
+				formatXRP(base), formatXRP(increment), s.network,
+				formatXRP(s.network.ReserveBaseDrops), formatXRP(s.network.ReserveIncrementDrops)),
+		})
+	}
+	return checks, nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMainnetGuard tests that transactions are only submitted to mainnet once confirmed
+func TestMainnetGuard(t *testing.T) {
+	s := &XRPLService{network: config.Networks["mainnet"]}
+	tx := transaction.FlatTransaction{"TransactionType": "AccountSet"}
+
+	// Refused before connecting to a node
+	_, err := s.SubmitTransactionContext(context.Background(), nil, tx)
+	assert.ErrorIs(t, err, ErrMainnetNotConfirmed)
+	assert.ErrorIs(t, s.checkWriteAllowed(), ErrMainnetNotConfirmed)
+
+	assert.NoError(t, s.WithMainnetConfirmed().checkWriteAllowed())
+	assert.False(t, s.mainnetConfirmed)
+
+	testnet := &XRPLService{network: config.Networks["testnet"]}
+	assert.NoError(t, testnet.checkWriteAllowed())
+	custom := &XRPLService{}
+	assert.NoError(t, custom.checkWriteAllowed())
+}
+
+// TestCheckNetworkID tests that nodes outside the profile are trusted only by the network ID they report
+func TestCheckNetworkID(t *testing.T) {
+	testnet := &XRPLService{network: config.Networks["testnet"]}
+	assert.NoError(t, testnet.checkNetworkID(1))
+	assert.Error(t, testnet.checkNetworkID(2))
+	assert.ErrorContains(t, testnet.WithMainnetConfirmed().checkNetworkID(0), "testnet expects 1")
+
+	// Nodes of no profile reporting network ID zero may be mainnet nodes
+	custom := &XRPLService{}
+	assert.NoError(t, custom.checkNetworkID(1))
+	assert.ErrorIs(t, custom.checkNetworkID(0), ErrMainnetNotConfirmed)
+	assert.NoError(t, custom.WithMainnetConfirmed().checkNetworkID(0))
+
+	// Standalone ledgers on another URL than the local profile's report network ID zero as expected
+	assert.False(t, config.Networks["local"].Includes("ws://localhost:6006"))
+	local := &XRPLService{network: config.Networks["local"]}
+	assert.NoError(t, local.checkNetworkID(0))
+	assert.Error(t, local.checkNetworkID(1))
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
+	"github.com/Peersyst/xrpl-go/xrpl/hash"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+// Number of ledgers a prepared transaction stays valid for, about an hour, leaving time to sign it offline
+const preparedLedgerOffset uint32 = 900
+
+// EncodedTransaction is a transaction in both JSON and binary form, as exchanged between the
+// prepare, sign and submit steps of the offline signing workflow
+type EncodedTransaction struct {
+	Transaction transaction.FlatTransaction `json:"transaction"`    // Transaction fields for review
+	TxBlob      string                      `json:"txBlob"`         // Binary encoded transaction, unsigned or signed
+	Hash        string                      `json:"hash,omitempty"` // Transaction hash, once signed
+}
+
+// WithPrepareOnly returns a service whose write operations autofill transactions from the network
+// and return them unsigned, for signing offline with SignOffline. Signers passed to write operations
+// only need their address. It shares the connection of s, close s when done.
+func (s *XRPLService) WithPrepareOnly() *XRPLService {
+	prepareOnly := *s
+	prepareOnly.prepareOnly = true
+	// Prepared sequences are only used once signed and submitted elsewhere, keep them apart from submitted ones
+	prepareOnly.sequences = newSequenceManager()
+	return &prepareOnly
+}
+
+// Autofill a transaction from the network and encode it unsigned.
+// Transactions prepared for the same account get consecutive sequences.
+func (s *XRPLService) prepare(ctx context.Context, flattenedTx transaction.FlatTransaction) (*TxResult, error) {
+	result := &TxResult{Prepared: true}
+
+	account, _ := flattenedTx["Account"].(string)
+	_, hasSequence := flattenedTx["Sequence"]
+	_, hasTicket := flattenedTx["TicketSequence"]
+	if account != "" && !hasSequence && !hasTicket {
+		span := uint32(1)
+		if ticketCount, ok := flattenedTx["TicketCount"].(uint32); ok {
+			span += ticketCount
+		}
+
+		accountSeq := s.sequences.account(account)
+		if err := accountSeq.lock(ctx); err != nil {
+			return nil, err
+		}
+		defer accountSeq.unlock()
+
+		seq, err := accountSeq.allocate(ctx, func(ctx context.Context) (uint32, error) {
+			return s.accountSequence(ctx, account)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get account sequence: %w", err)
+		}
+		flattenedTx["Sequence"] = seq
+		accountSeq.commit(seq, span)
+		accountSeq.forget(seq)
+	}
+
+	node, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		// Leave time for offline signing before the transaction expires
+		if _, ok := flattenedTx["LastLedgerSequence"]; !ok {
+			validatedLedger, err := client.GetLedgerIndex()
+			if err != nil {
+				return fmt.Errorf("unable to get validated ledger: %w", err)
+			}
+			flattenedTx["LastLedgerSequence"] = validatedLedger.Uint32() + preparedLedgerOffset
+		}
+		return s.autofill(client, flattenedTx, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Multisigned transactions carry an empty signing key
+	if s.multisigners > 0 {
+		flattenedTx["SigningPubKey"] = ""
+	}
+	blob, err := binarycodec.Encode(flattenedTx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode transaction: %w", err)
+	}
+	result.Node = node
+	result.Transaction = flattenedTx
+	result.Blob = blob
+	result.Fee, _ = flattenedTx["Fee"].(string)
+	result.Sequence, _ = flattenedTx["Sequence"].(uint32)
+	result.TicketSequence, _ = flattenedTx["TicketSequence"].(uint32)
+	result.ResultMessage = "Prepared, not signed. Sign it offline, then submit the signed blob."
+	return result, nil
+}
+
+// PrepareTransaction calls PrepareTransactionContext with a background context
+func (s *XRPLService) PrepareTransaction(flattenedTx transaction.FlatTransaction) (*TxResult, error) {
+	return s.PrepareTransactionContext(context.Background(), flattenedTx)
+}
+
+// PrepareTransactionContext autofills any transaction from the network and returns it unsigned for offline signing,
+// for multisigning when s was created with WithMultisignPrepare
+func (s *XRPLService) PrepareTransactionContext(ctx context.Context, flattenedTx transaction.FlatTransaction) (*TxResult, error) {
+	if !s.prepareOnly {
+		s = s.WithPrepareOnly()
+	}
+	return s.prepare(ctx, flattenedTx)
+}
+
+// ParseTransactionJSON parses a transaction in rippled JSON format, converting numbers to the integer
+// types of their fields so the transaction can be encoded
+func ParseTransactionJSON(data []byte) (transaction.FlatTransaction, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tx map[string]any
+	if err := decoder.Decode(&tx); err != nil {
+		return nil, fmt.Errorf("invalid transaction JSON: %w", err)
+	}
+	if _, ok := tx["TransactionType"].(string); !ok {
+		return nil, fmt.Errorf("transaction has no TransactionType")
+	}
+	if err := convertFields(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Convert JSON numbers of an object and its nested objects to the types the binary codec expects
+func convertFields(object map[string]any) error {
+	for field, value := range object {
+		switch v := value.(type) {
+		case map[string]any:
+			if err := convertFields(v); err != nil {
+				return err
+			}
+		case []any:
+			for _, item := range v {
+				if nested, ok := item.(map[string]any); ok {
+					if err := convertFields(nested); err != nil {
+						return err
+					}
+				}
+			}
+		case json.Number:
+			typeName, err := definitions.Get().GetTypeNameByFieldName(field)
+			if err != nil {
+				return fmt.Errorf("unknown field %s: %w", field, err)
+			}
+			bits := map[string]int{"UInt8": 8, "UInt16": 16, "UInt32": 32}[typeName]
+			if bits == 0 {
+				return fmt.Errorf("field %s of type %s cannot be a number", field, typeName)
+			}
+			number, err := strconv.ParseUint(v.String(), 10, bits)
+			if err != nil {
+				return fmt.Errorf("invalid %s value %s: %w", field, v, err)
+			}
+			// The codec takes 32-bit fields as uint32 and narrower ones as int
+			if typeName == "UInt32" {
+				object[field] = uint32(number)
+			} else {
+				object[field] = int(number)
+			}
+		}
+	}
+	return nil
+}
+
+// SignOffline signs a prepared transaction blob without access to the XRP Ledger.
+// Only a remote signer contacts its signing service.
+func SignOffline(ctx context.Context, signer Signer, txBlob string) (*EncodedTransaction, error) {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+	if _, signed := tx["TxnSignature"]; signed {
+		return nil, fmt.Errorf("transaction is already signed")
+	}
+
+	signedBlob, txHash, err := signer.Sign(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign transaction: %w", err)
+	}
+
+	return &EncodedTransaction{
+		Transaction: tx,
+		TxBlob:      signedBlob,
+		Hash:        txHash,
+	}, nil
+}
+
+// SubmitSignedTransaction calls SubmitSignedTransactionContext with a background context
+func (s *XRPLService) SubmitSignedTransaction(txBlob string) (*TxResult, error) {
+	return s.SubmitSignedTransactionContext(context.Background(), txBlob)
+}
+
+// SubmitSignedTransactionContext submits a transaction signed elsewhere, e.g. offline, and tracks it until its outcome is final.
+// In dry-run mode the transaction is decoded and checked but not submitted.
+func (s *XRPLService) SubmitSignedTransactionContext(ctx context.Context, txBlob string) (*TxResult, error) {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+	if _, signed := tx["TxnSignature"]; !signed {
+		if _, multisigned := tx["Signers"]; !multisigned {
+			return nil, fmt.Errorf("transaction is not signed")
+		}
+	}
+	txHash, err := hash.SignTxBlob(txBlob)
+	if err != nil {
+		return nil, fmt.Errorf("unable to hash transaction: %w", err)
+	}
+
+	result := &TxResult{Hash: txHash}
+	result.Fee, _ = tx["Fee"].(string)
+	result.Sequence, _ = tx["Sequence"].(uint32)
+	result.TicketSequence, _ = tx["TicketSequence"].(uint32)
+
+	if s.dryRun {
+		result.DryRun = true
+		result.Transaction = tx
+		result.Blob = txBlob
+		result.Checks, err = s.preflight(ctx, tx)
+		if err != nil {
+			return result, fmt.Errorf("unable to run preflight checks: %w", err)
+		}
+		result.ResultMessage = "Dry run, not submitted."
+		return result, nil
+	}
+	if err := s.checkWriteAllowed(); err != nil {
+		return result, err
+	}
+
+	account, _ := tx["Account"].(string)
+	sub := &submission{account: account, blob: txBlob}
+	sub.lastLedgerSequence, _ = tx["LastLedgerSequence"].(uint32)
+	if sub.lastLedgerSequence == 0 {
+		return nil, fmt.Errorf("transaction has no LastLedgerSequence, its outcome could never become final")
+	}
+
+	response, node, err := s.submitBlob(ctx, txBlob)
+	if err != nil {
+		return nil, err
+	}
+	result.Node = node
+	if err := submitOutcome(result, response); err != nil {
+		return result, err
+	}
+	return result, s.awaitValidation(ctx, sub, result)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/pkg/crypto"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignOffline tests signing a prepared transaction blob and submitting only signed blobs
+func TestSignOffline(t *testing.T) {
+	signer, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+
+	prepared, err := binarycodec.Encode(transaction.FlatTransaction{
+		"TransactionType":    "AccountSet",
+		"Account":            signer.ClassicAddress.String(),
+		"Fee":                "12",
+		"Sequence":           uint32(7),
+		"LastLedgerSequence": uint32(1000),
+	})
+	require.NoError(t, err)
+
+	signed, err := SignOffline(context.Background(), NewWalletSigner(&signer), prepared)
+	require.NoError(t, err)
+	assert.Equal(t, signer.PublicKey, signed.Transaction["SigningPubKey"])
+	assert.NotEmpty(t, signed.Transaction["TxnSignature"])
+	assert.Len(t, signed.Hash, 64)
+
+	_, err = SignOffline(context.Background(), NewWalletSigner(&signer), signed.TxBlob)
+	assert.Error(t, err)
+
+	s := &XRPLService{}
+	_, err = s.SubmitSignedTransactionContext(context.Background(), prepared)
+	assert.ErrorContains(t, err, "not signed")
+}
+
+// TestParseTransactionJSON tests converting JSON numbers to the integer types of their fields
+func TestParseTransactionJSON(t *testing.T) {
+	tx, err := ParseTransactionJSON([]byte(`{"TransactionType":"SignerListSet","Account":"rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn","SignerQuorum":2,
+		"SignerEntries":[{"SignerEntry":{"Account":"rsA2LpzuawewSBQXkiju3YQTMzW13pAAdW","SignerWeight":2}}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), tx["SignerQuorum"])
+	_, err = binarycodec.Encode(tx)
+	assert.NoError(t, err)
+
+	_, err = ParseTransactionJSON([]byte(`{"TransactionType":"AccountSet","Sequence":-1}`))
+	assert.Error(t, err)
+	_, err = ParseTransactionJSON([]byte(`{"Account":"rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"}`))
+	assert.Error(t, err)
+}
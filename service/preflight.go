@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+// AccountRoot flags
+const (
+	lsfRequireAuth            uint32 = 0x00040000
+	lsfNoFreeze               uint32 = 0x00200000
+	lsfGlobalFreeze           uint32 = 0x00400000
+	lsfAllowTrustLineClawback uint32 = 0x80000000
+)
+
+// AccountSet flags that can only be enabled while the account owns no ledger objects
+const (
+	asfRequireAuth            uint32 = 2
+	asfAllowTrustLineClawback uint32 = 16
+)
+
+// TrustSet flags
+const (
+	tfSetAuth         uint32 = 0x00010000
+	tfSetFreeze       uint32 = 0x00100000
+	tfClearFreeze     uint32 = 0x00200000
+	tfSetDeepFreeze   uint32 = 0x00400000
+	tfClearDeepFreeze uint32 = 0x00800000
+)
+
+// PreflightCheck is the outcome of a check run against the ledger before a transaction is submitted
+type PreflightCheck struct {
+	Name   string `json:"name"`   // What was checked
+	Passed bool   `json:"passed"` // Whether the transaction is expected to pass this check
+	Detail string `json:"detail"` // Values the check was based on
+}
+
+// WithDryRun returns a service whose write operations build, autofill and sign transactions and run
+// preflight checks without submitting them. It shares the connection of s, close s when done.
+func (s *XRPLService) WithDryRun() *XRPLService {
+	dryRun := *s
+	dryRun.dryRun = true
+	return &dryRun
+}
+
+// Build, autofill and sign a transaction and run preflight checks instead of submitting it
+func (s *XRPLService) simulate(ctx context.Context, signer Signer, flattenedTx transaction.FlatTransaction) (*TxResult, error) {
+	result := &TxResult{DryRun: true}
+
+	node, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		return s.autofill(client, flattenedTx, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Node = node
+
+	// Sign after releasing the connection, like a submitted transaction
+	result.Blob, result.Hash, err = signer.Sign(ctx, flattenedTx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign transaction: %w", err)
+	}
+	result.Transaction = flattenedTx
+	result.Fee, _ = flattenedTx["Fee"].(string)
+	result.Sequence, _ = flattenedTx["Sequence"].(uint32)
+	result.TicketSequence, _ = flattenedTx["TicketSequence"].(uint32)
+
+	result.Checks, err = s.preflight(ctx, flattenedTx)
+	if err != nil {
+		return result, fmt.Errorf("unable to run preflight checks: %w", err)
+	}
+
+	failed := 0
+	for _, check := range result.Checks {
+		if !check.Passed {
+			failed++
+		}
+	}
+	if failed == 0 {
+		result.ResultMessage = "Dry run, not submitted. All preflight checks passed."
+	} else {
+		result.ResultMessage = fmt.Sprintf("Dry run, not submitted. %d of %d preflight checks failed.", failed, len(result.Checks))
+	}
+	return result, nil
+}
+
+// Check the sending account, reserves, trust lines and flags a transaction depends on
+func (s *XRPLService) preflight(ctx context.Context, tx transaction.FlatTransaction) ([]PreflightCheck, error) {
+	var checks []PreflightCheck
+	address, _ := tx["Account"].(string)
+
+	sender, err := s.ledgerAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if sender == nil {
+		return append(checks, PreflightCheck{Name: "account", Passed: false, Detail: fmt.Sprintf("account %s does not exist", address)}), nil
+	}
+	checks = append(checks, PreflightCheck{Name: "account", Passed: true, Detail: fmt.Sprintf("account %s exists", address)})
+
+	// A single signature must come from the master key, unless disabled, or the regular key
+	if signingPubKey, _ := tx["SigningPubKey"].(string); signingPubKey != "" {
+		checks = append(checks, signingKeyCheck(address, signingPubKey, sender.RegularKey, sender.Flags))
+	}
+
+	// Multisignatures must add up to the quorum of the account's signer list
+	if txSigners, ok := tx["Signers"].([]any); ok && len(txSigners) > 0 {
+		check, err := s.quorumCheck(ctx, address, txSigners)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+
+	// Ledger objects the transaction adds to the sender's owner reserve
+	newObjects := uint32(0)
+	txType, _ := tx["TransactionType"].(string)
+	flags, _ := tx["Flags"].(uint32)
+
+	switch txType {
+	case "Payment":
+		amount, ok := tx["Amount"].(map[string]any)
+		if !ok {
+			break
+		}
+		destination, _ := tx["Destination"].(string)
+		paymentChecks, err := s.paymentChecks(ctx, address, destination, amount)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, paymentChecks...)
+
+	case "TrustSet":
+		limit, _ := tx["LimitAmount"].(map[string]any)
+		issuer, _ := limit["issuer"].(string)
+		currency, _ := limit["currency"].(string)
+
+		peer, err := s.ledgerAccount(ctx, issuer)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, PreflightCheck{Name: "counterparty", Passed: peer != nil, Detail: fmt.Sprintf("account %s exists: %t", issuer, peer != nil)})
+
+		line, err := s.ledgerTrustLine(ctx, address, issuer, currency)
+		if err != nil {
+			return nil, err
+		}
+		if line == nil {
+			newObjects++
+		}
+		if flags&(tfSetFreeze|tfClearFreeze|tfSetDeepFreeze|tfClearDeepFreeze|tfSetAuth) != 0 {
+			checks = append(checks, PreflightCheck{Name: "trust line", Passed: line != nil, Detail: fmt.Sprintf("%s trust line with %s exists: %t", currency, issuer, line != nil)})
+		}
+		if flags&(tfSetFreeze|tfSetDeepFreeze) != 0 {
+			checks = append(checks, PreflightCheck{Name: "freeze allowed", Passed: sender.Flags&lsfNoFreeze == 0,
+				Detail: fmt.Sprintf("NoFreeze enabled on %s: %t", address, sender.Flags&lsfNoFreeze != 0)})
+		}
+		if line != nil {
+			checks = append(checks, deepFreezeChecks(line, flags)...)
+		}
+		if flags&tfSetAuth != 0 {
+			checks = append(checks, PreflightCheck{Name: "authorization required", Passed: sender.Flags&lsfRequireAuth != 0,
+				Detail: fmt.Sprintf("RequireAuth enabled on %s: %t", address, sender.Flags&lsfRequireAuth != 0)})
+		}
+
+	case "Clawback":
+		// The issuer field of a clawback amount names the holder
+		amount, _ := tx["Amount"].(map[string]any)
+		holder, _ := amount["issuer"].(string)
+		currency, _ := amount["currency"].(string)
+
+		checks = append(checks, PreflightCheck{Name: "clawback allowed", Passed: sender.Flags&lsfAllowTrustLineClawback != 0,
+			Detail: fmt.Sprintf("Allow Trust Line Clawback enabled on %s: %t", address, sender.Flags&lsfAllowTrustLineClawback != 0)})
+
+		line, err := s.ledgerTrustLine(ctx, holder, address, currency)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, holderBalanceCheck(holder, currency, line))
+
+	case "AccountSet":
+		setFlag, _ := tx["SetFlag"].(uint32)
+		if setFlag == asfRequireAuth || setFlag == asfAllowTrustLineClawback {
+			checks = append(checks, PreflightCheck{Name: "empty owner directory", Passed: sender.OwnerCount == 0,
+				Detail: fmt.Sprintf("account owns %d ledger objects, flag %d can only be enabled when it owns none", sender.OwnerCount, setFlag)})
+		}
+		// NoFreeze also gives up lifting a global freeze
+		if clearFlag, _ := tx["ClearFlag"].(uint32); clearFlag == asfGlobalFreeze {
+			checks = append(checks, PreflightCheck{Name: "unfreeze allowed", Passed: sender.Flags&lsfNoFreeze == 0,
+				Detail: fmt.Sprintf("NoFreeze enabled on %s: %t", address, sender.Flags&lsfNoFreeze != 0)})
+		}
+		if setFlag == asfDisableMaster {
+			check, err := s.alternativeKeyCheck(ctx, address, sender.RegularKey)
+			if err != nil {
+				return nil, err
+			}
+			checks = append(checks, check)
+		}
+
+	case "SetRegularKey":
+		// Removing the regular key of an account with a disabled master key needs a signer list to remain
+		if _, ok := tx["RegularKey"]; !ok && sender.Flags&lsfDisableMaster != 0 {
+			check, err := s.alternativeKeyCheck(ctx, address, "")
+			if err != nil {
+				return nil, err
+			}
+			checks = append(checks, check)
+		}
+
+	case "SignerListSet":
+		quorum, _ := tx["SignerQuorum"].(uint32)
+		list, err := s.SignerListContext(ctx, types.Address(address))
+		if err != nil {
+			return nil, err
+		}
+		if list == nil && quorum > 0 {
+			newObjects++
+		}
+
+	case "TicketCreate":
+		ticketCount, _ := tx["TicketCount"].(uint32)
+		newObjects += ticketCount
+	}
+
+	// The balance must cover the fee and the reserve including any new ledger objects
+	reserveBase, reserveInc, err := s.reserves(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fee, _ := strconv.ParseUint(fmt.Sprint(tx["Fee"]), 10, 64)
+	required := reserveBase + reserveInc*uint64(sender.OwnerCount+newObjects) + fee
+	checks = append(checks, PreflightCheck{Name: "reserve", Passed: sender.Balance.Uint64() >= required,
+		Detail: fmt.Sprintf("balance %d drops, reserve and fee require %d drops", sender.Balance.Uint64(), required)})
+
+	return checks, nil
+}
+
+// Check the trust lines and freeze state an issued currency payment depends on
+func (s *XRPLService) paymentChecks(ctx context.Context, sender, destination string, amount map[string]any) ([]PreflightCheck, error) {
+	var checks []PreflightCheck
+	currency, _ := amount["currency"].(string)
+	issuer, _ := amount["issuer"].(string)
+	value, _ := strconv.ParseFloat(fmt.Sprint(amount["value"]), 64)
+
+	receiver, err := s.ledgerAccount(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+	checks = append(checks, PreflightCheck{Name: "destination", Passed: receiver != nil, Detail: fmt.Sprintf("account %s exists: %t", destination, receiver != nil)})
+
+	issuerAccount, err := s.ledgerAccount(ctx, issuer)
+	if err != nil {
+		return nil, err
+	}
+	// Payments to and from the issuer itself are not affected by a global freeze
+	if issuerAccount != nil {
+		globalFreeze := issuerAccount.Flags&lsfGlobalFreeze != 0
+		checks = append(checks, PreflightCheck{Name: "global freeze", Passed: !globalFreeze || sender == issuer || destination == issuer,
+			Detail: fmt.Sprintf("issuer %s global freeze enabled: %t", issuer, globalFreeze)})
+	}
+
+	// The issuer has no trust line to itself
+	if sender != issuer {
+		line, err := s.ledgerTrustLine(ctx, sender, issuer, currency)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, lineCheck("sender trust line", sender, currency, line, func(balance, limit float64) (bool, string) {
+			return balance >= value, fmt.Sprintf("balance %g, sending %g", balance, value)
+		}))
+	}
+	if destination != issuer && receiver != nil {
+		line, err := s.ledgerTrustLine(ctx, destination, issuer, currency)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, lineCheck("receiver trust line", destination, currency, line, func(balance, limit float64) (bool, string) {
+			return limit-balance >= value, fmt.Sprintf("balance %g, limit %g, receiving %g", balance, limit, value)
+		}))
+		if line != nil && issuerAccount != nil && issuerAccount.Flags&lsfRequireAuth != 0 {
+			checks = append(checks, PreflightCheck{Name: "receiver authorized", Passed: line.PeerAuthorized,
+				Detail: fmt.Sprintf("issuer requires authorization, %s trust line authorized: %t", destination, line.PeerAuthorized)})
+		}
+	}
+	return checks, nil
+}
+
+// Check that a trust line exists, is not frozen and satisfies the amount check
+func lineCheck(name, owner, currency string, line *TrustLine, amountCheck func(balance, limit float64) (bool, string)) PreflightCheck {
+	if line == nil {
+		return PreflightCheck{Name: name, Passed: false, Detail: fmt.Sprintf("%s has no %s trust line", owner, currency)}
+	}
+	if line.Freeze || line.FreezePeer {
+		return PreflightCheck{Name: name, Passed: false, Detail: fmt.Sprintf("%s %s trust line is frozen", owner, currency)}
+	}
+	balance, _ := strconv.ParseFloat(line.Balance, 64)
+	limit, _ := strconv.ParseFloat(line.Limit, 64)
+	passed, detail := amountCheck(balance, limit)
+	return PreflightCheck{Name: name, Passed: passed, Detail: detail}
+}
+
+// Get an account from the current ledger, nil if it does not exist
+func (s *XRPLService) ledgerAccount(ctx context.Context, address string) (*ledger.AccountRoot, error) {
+	var resp *account.InfoResponse
+	_, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		var err error
+		resp, err = client.GetAccountInfo(&account.InfoRequest{Account: types.Address(address)})
+		return err
+	})
+	if isXRPLError(err, "actNotFound") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp.AccountData, nil
+}
+
+// Get the trust line between an account and a peer for a currency, nil if there is none
+func (s *XRPLService) ledgerTrustLine(ctx context.Context, address, peer, currency string) (*TrustLine, error) {
+	resp, err := s.accountLines(ctx, types.Address(address), types.Address(peer))
+	if isXRPLError(err, "actNotFound") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range resp.Lines {
+		if line.Currency == currency {
+			return &line, nil
+		}
+	}
+	return nil, nil
+}
+
+// Get the base and owner reserves in drops from the latest validated ledger
+func (s *XRPLService) reserves(ctx context.Context) (uint64, uint64, error) {
+	resp, err := s.serverInfo(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	validated := resp.Info.ValidatedLedger
+	return uint64(float64(validated.ReserveBaseXRP)*1e6 + 0.5), uint64(float64(validated.ReserveIncXRP)*1e6 + 0.5), nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLineCheck tests preflight checks of missing, frozen and funded trust lines
+func TestLineCheck(t *testing.T) {
+	enough := func(balance, limit float64) (bool, string) {
+		return balance >= 10, ""
+	}
+
+	assert.False(t, lineCheck("sender trust line", "rSender", "USD", nil, enough).Passed)
+	assert.False(t, lineCheck("sender trust line", "rSender", "USD", &TrustLine{Balance: "50", FreezePeer: true}, enough).Passed)
+	assert.False(t, lineCheck("sender trust line", "rSender", "USD", &TrustLine{Balance: "5"}, enough).Passed)
+	assert.True(t, lineCheck("sender trust line", "rSender", "USD", &TrustLine{Balance: "50"}, enough).Passed)
+}
+
+// TestHolderBalanceCheck tests checking the balance a clawback takes from
+func TestHolderBalanceCheck(t *testing.T) {
+	assert.False(t, holderBalanceCheck("rHolder", "USD", nil).Passed)
+	assert.False(t, holderBalanceCheck("rHolder", "USD", &TrustLine{Balance: "0"}).Passed)
+	// Frozen lines can still be clawed back
+	assert.True(t, holderBalanceCheck("rHolder", "USD", &TrustLine{Balance: "25", FreezePeer: true}).Passed)
+}
+
+// TestClawbackRefusal tests that clawbacks are refused before submitting when the ledger would reject them
+func TestClawbackRefusal(t *testing.T) {
+	issuer := &ledger.AccountRoot{Account: "rIssuer"}
+	funded := &TrustLine{Balance: "25"}
+	assert.ErrorIs(t, clawbackRefusal(issuer, "rHolder", "USD", funded), ErrClawbackNotEnabled)
+	issuer.Flags = lsfNoFreeze
+	assert.ErrorIs(t, clawbackRefusal(issuer, "rHolder", "USD", funded), ErrClawbackNotEnabled)
+
+	// An explicit amount is refused as well when the holder has nothing to claw back
+	issuer.Flags = lsfAllowTrustLineClawback
+	assert.NoError(t, clawbackRefusal(issuer, "rHolder", "USD", funded))
+	assert.ErrorIs(t, clawbackRefusal(issuer, "rHolder", "USD", &TrustLine{Balance: "0"}), ErrNothingToClawBack)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+)
+
+const (
+	// Longest vanity prefix searched for after the leading r, each character multiplies the attempts by 58
+	maxVanityLength = 3
+	// Wallets generated before giving up on a vanity prefix
+	maxVanityAttempts = 2000000
+	// Trust limit of provisioned trust lines when none is given
+	defaultTrustLimit = "1000000000"
+)
+
+// Characters of base58 XRP Ledger addresses
+const addressAlphabet = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+
+// Roles of provisioned accounts
+const (
+	RoleIssuer      = "issuer"
+	RoleDistributor = "distributor"
+	RoleHolder      = "holder"
+)
+
+// ProvisionOptions describes a set of test accounts to create, fund and set up
+type ProvisionOptions struct {
+	Count        int                  // Number of accounts
+	Account      CreateAccountOptions // Key algorithm or mnemonic of the accounts
+	Vanity       string               // Address prefix, such as rXRP, searched for among random wallets
+	Issuer       bool                 // Configure the first account as token issuer with the default settings
+	Distributors bool                 // Configure the other accounts as distributors with the default settings
+	TokenName    string               // Token the other accounts trust the issuer for, none if empty
+	TrustLimit   string               // Limit of the trust lines, default 1000000000
+}
+
+// ProvisionedAccount is the outcome of provisioning a single account
+type ProvisionedAccount struct {
+	NewAccount
+	Role    string      // Role of the account in the set
+	Balance string      // XRP balance once funded, empty if the account was not funded
+	Results []*TxResult // Setup transactions in the order they were submitted
+	Err     error       // Reason provisioning stopped for this account, nil on success
+}
+
+// GenerateAccounts creates the wallets of a set of test accounts without touching the ledger,
+// so they can be stored before they are funded
+func (s *XRPLService) GenerateAccounts(options *ProvisionOptions) ([]NewAccount, error) {
+	count := max(options.Count, 1)
+	if err := options.check(count); err != nil {
+		return nil, err
+	}
+	accountOptions := options.Account
+
+	// All accounts derive from the same mnemonic
+	if accountOptions.Mnemonic || accountOptions.RestoreMnemonic != "" {
+		if options.Vanity != "" {
+			return nil, fmt.Errorf("vanity addresses cannot be derived from a mnemonic")
+		}
+		accountOptions.Count = count
+		return s.CreateAccountWithOptions(&accountOptions)
+	}
+
+	accountOptions.Count = 1
+	accounts := make([]NewAccount, 0, count)
+	for i := 0; i < count; i++ {
+		if options.Vanity != "" {
+			vanity, err := vanityWallet(accountOptions.Algorithm, options.Vanity)
+			if err != nil {
+				return nil, err
+			}
+			accounts = append(accounts, NewAccount{Wallet: vanity})
+			continue
+		}
+		created, err := s.CreateAccountWithOptions(&accountOptions)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, created...)
+	}
+	return accounts, nil
+}
+
+// Check the options can provision count accounts
+func (o *ProvisionOptions) check(count int) error {
+	if o.TokenName != "" && (!o.Issuer || count < 2) {
+		return fmt.Errorf("trust lines need an issuer and at least one other account")
+	}
+	return nil
+}
+
+// Generate random wallets until one has an address starting with prefix
+func vanityWallet(algorithmName, prefix string) (*wallet.Wallet, error) {
+	rest, ok := strings.CutPrefix(prefix, "r")
+	if !ok || rest == "" || len(rest) > maxVanityLength {
+		return nil, fmt.Errorf("invalid vanity prefix %q, use r followed by 1 to %d characters", prefix, maxVanityLength)
+	}
+	for _, c := range rest {
+		if !strings.ContainsRune(addressAlphabet, c) {
+			return nil, fmt.Errorf("invalid vanity prefix %q, %q never appears in addresses", prefix, c)
+		}
+	}
+	algorithm, err := keyAlgorithm(algorithmName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < maxVanityAttempts; i++ {
+		candidate, err := wallet.New(algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create wallet: %w", err)
+		}
+		if strings.HasPrefix(candidate.ClassicAddress.String(), prefix) {
+			return &candidate, nil
+		}
+	}
+	return nil, fmt.Errorf("no address starting with %s found in %d attempts, try a shorter prefix", prefix, maxVanityAttempts)
+}
+
+// ProvisionAccounts calls ProvisionAccountsContext with a background context
+func (s *XRPLService) ProvisionAccounts(accounts []NewAccount, options *ProvisionOptions) ([]ProvisionedAccount, error) {
+	return s.ProvisionAccountsContext(context.Background(), accounts, options)
+}
+
+// ProvisionAccountsContext funds the accounts from the configured faucet, waits until each exists in a validated ledger
+// and applies the requested settings and trust lines. The first account is the issuer when options.Issuer
+// is set. Results are in the order of accounts and report their own failures; the error is only
+// returned for invalid options.
+func (s *XRPLService) ProvisionAccountsContext(ctx context.Context, accounts []NewAccount, options *ProvisionOptions) ([]ProvisionedAccount, error) {
+	if err := options.check(len(accounts)); err != nil {
+		return nil, err
+	}
+	if s.faucet == nil {
+		return nil, fmt.Errorf("no faucet configured to fund the accounts")
+	}
+
+	results := make([]ProvisionedAccount, len(accounts))
+	for i, newAccount := range accounts {
+		results[i].NewAccount = newAccount
+		switch {
+		case options.Issuer && i == 0:
+			results[i].Role = RoleIssuer
+		case options.Distributors:
+			results[i].Role = RoleDistributor
+		default:
+			results[i].Role = RoleHolder
+		}
+	}
+
+	// Request funding one account at a time, faucets throttle bursts, then wait for all payments together
+	for i := range results {
+		if _, _, err := s.faucet.Fund(ctx, results[i].Wallet.ClassicAddress); err != nil {
+			results[i].Err = fmt.Errorf("failed to fund account: %w", err)
+		}
+	}
+	eachAccount(results, func(result *ProvisionedAccount) {
+		balance, err := s.waitForBalance(ctx, result.Wallet.ClassicAddress, 0)
+		if result.Err = err; err == nil {
+			result.Balance = formatXRP(balance)
+		}
+	})
+
+	if options.Issuer {
+		issuer := &results[0]
+		if issuer.Err == nil {
+			result, err := s.ConfigureIssuerAccountContext(ctx, NewWalletSigner(issuer.Wallet), nil)
+			issuer.addResult(result, err)
+		}
+		if issuer.Err != nil {
+			for i := range results[1:] {
+				if results[i+1].Err == nil {
+					results[i+1].Err = fmt.Errorf("issuer %s was not provisioned", issuer.Wallet.ClassicAddress)
+				}
+			}
+			return results, nil
+		}
+	}
+
+	trustLimit := options.TrustLimit
+	if trustLimit == "" {
+		trustLimit = defaultTrustLimit
+	}
+	eachAccount(results, func(result *ProvisionedAccount) {
+		signer := NewWalletSigner(result.Wallet)
+		if result.Role == RoleDistributor {
+			if !result.addResult(s.ConfigureDistributorAccountContext(ctx, signer, nil)) {
+				return
+			}
+		}
+		if options.TokenName != "" && result.Role != RoleIssuer {
+			result.addResult(s.CreateTrustLineContext(ctx, signer, &TrustLineOptions{
+				IssuerAddress: results[0].Wallet.ClassicAddress,
+				TokenName:     options.TokenName,
+				Amount:        trustLimit,
+			}))
+		}
+	})
+	return results, nil
+}
+
+// Record the outcome of a setup transaction, reporting whether it succeeded
+func (p *ProvisionedAccount) addResult(result *TxResult, err error) bool {
+	if result != nil {
+		p.Results = append(p.Results, result)
+	}
+	p.Err = err
+	return err == nil
+}
+
+// Run fn concurrently for every account that has not failed yet
+func eachAccount(results []ProvisionedAccount, fn func(result *ProvisionedAccount)) {
+	var wg sync.WaitGroup
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(result *ProvisionedAccount) {
+			defer wg.Done()
+			fn(result)
+		}(&results[i])
+	}
+	wg.Wait()
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateAccounts tests generating the wallets of a set of test accounts
+func TestGenerateAccounts(t *testing.T) {
+	s := &XRPLService{}
+
+	accounts, err := s.GenerateAccounts(&ProvisionOptions{Count: 3})
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	assert.NotEqual(t, accounts[0].Wallet.ClassicAddress, accounts[1].Wallet.ClassicAddress)
+
+	accounts, err = s.GenerateAccounts(&ProvisionOptions{Count: 2, Vanity: "rp"})
+	require.NoError(t, err)
+	for _, account := range accounts {
+		assert.True(t, strings.HasPrefix(account.Wallet.ClassicAddress.String(), "rp"))
+	}
+
+	accounts, err = s.GenerateAccounts(&ProvisionOptions{Count: 2, Account: CreateAccountOptions{Mnemonic: true}})
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	assert.Equal(t, accounts[0].Mnemonic, accounts[1].Mnemonic)
+	assert.Equal(t, "m/44'/144'/0'/0/1", accounts[1].DerivationPath)
+
+	_, err = s.GenerateAccounts(&ProvisionOptions{Vanity: "rp", Account: CreateAccountOptions{Mnemonic: true}})
+	assert.Error(t, err)
+	_, err = s.GenerateAccounts(&ProvisionOptions{Vanity: "p0"})
+	assert.Error(t, err)
+	_, err = s.GenerateAccounts(&ProvisionOptions{Vanity: "rXRPL"})
+	assert.Error(t, err)
+
+	// Trust lines are set up towards the issuer
+	_, err = s.ProvisionAccountsContext(context.Background(), accounts, &ProvisionOptions{TokenName: "USD"})
+	assert.Error(t, err)
+}
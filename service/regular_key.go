@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	addresscodec "github.com/Peersyst/xrpl-go/address-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// AccountRoot flag set once the master key can no longer sign for the account
+const lsfDisableMaster uint32 = 0x00100000
+
+// AccountSet flag disabling the master key
+const asfDisableMaster uint32 = 4
+
+// AccountKeys describes which keys can sign for an account
+type AccountKeys struct {
+	Account        types.Address `json:"account"`
+	RegularKey     types.Address `json:"regularKey,omitempty"` // Address of the regular key pair, empty if none is set
+	MasterDisabled bool          `json:"masterDisabled"`       // Whether the master key can no longer sign
+	SignerList     *SignerList   `json:"signerList,omitempty"` // Signer list for multisigning, nil if none is set
+}
+
+// SetRegularKey calls SetRegularKeyContext with a background context
+func (s *XRPLService) SetRegularKey(signer Signer, regularKey types.Address) (*TxResult, error) {
+	return s.SetRegularKeyContext(context.Background(), signer, regularKey)
+}
+
+// SetRegularKeyContext assigns a regular key pair to the signer's account, replacing any current one.
+// An empty regularKey removes the regular key. The signer may hold the master key or the current regular key.
+func (s *XRPLService) SetRegularKeyContext(ctx context.Context, signer Signer, regularKey types.Address) (*TxResult, error) {
+	if regularKey != "" {
+		if !addresscodec.IsValidClassicAddress(regularKey.String()) {
+			return nil, fmt.Errorf("invalid regular key address %q", regularKey)
+		}
+		if regularKey == signer.Address() {
+			return nil, fmt.Errorf("regular key must differ from the master key of account %s", signer.Address())
+		}
+	}
+
+	setRegularKey := &transaction.SetRegularKey{
+		BaseTx: transaction.BaseTx{
+			Account: signer.Address(),
+		},
+		RegularKey: regularKey,
+	}
+
+	// Autofill, sign, submit and track transaction to its final outcome
+	result, err := s.SubmitTransactionContext(ctx, signer, setRegularKey.Flatten())
+	if err != nil {
+		return result, fmt.Errorf("unable to set regular key: %w", err)
+	}
+	return result, nil
+}
+
+// DisableMasterKey calls DisableMasterKeyContext with a background context
+func (s *XRPLService) DisableMasterKey(signer Signer) (*TxResult, error) {
+	return s.DisableMasterKeyContext(context.Background(), signer)
+}
+
+// DisableMasterKeyContext stops the master key from signing for the signer's account, which requires a regular key or signer list
+func (s *XRPLService) DisableMasterKeyContext(ctx context.Context, signer Signer) (*TxResult, error) {
+	accountSet := &transaction.AccountSet{
+		BaseTx: transaction.BaseTx{
+			Account: signer.Address(),
+		},
+	}
+	accountSet.SetAsfDisableMaster()
+
+	// Autofill, sign, submit and track transaction to its final outcome
+	result, err := s.SubmitTransactionContext(ctx, signer, accountSet.Flatten())
+	if err != nil {
+		return result, fmt.Errorf("unable to disable master key: %w", err)
+	}
+	return result, nil
+}
+
+// EnableMasterKey calls EnableMasterKeyContext with a background context
+func (s *XRPLService) EnableMasterKey(signer Signer) (*TxResult, error) {
+	return s.EnableMasterKeyContext(context.Background(), signer)
+}
+
+// EnableMasterKeyContext allows the master key to sign for the signer's account again; the signer must hold the regular key
+func (s *XRPLService) EnableMasterKeyContext(ctx context.Context, signer Signer) (*TxResult, error) {
+	accountSet := &transaction.AccountSet{
+		BaseTx: transaction.BaseTx{
+			Account: signer.Address(),
+		},
+	}
+	accountSet.ClearAsfDisableMaster()
+
+	// Autofill, sign, submit and track transaction to its final outcome
+	result, err := s.SubmitTransactionContext(ctx, signer, accountSet.Flatten())
+	if err != nil {
+		return result, fmt.Errorf("unable to enable master key: %w", err)
+	}
+	return result, nil
+}
+
+// AccountKeys calls AccountKeysContext with a background context
+func (s *XRPLService) AccountKeys(address types.Address) (*AccountKeys, error) {
+	return s.AccountKeysContext(context.Background(), address)
+}
+
+// AccountKeysContext returns the regular key, master key status and signer list of an account
+func (s *XRPLService) AccountKeysContext(ctx context.Context, address types.Address) (*AccountKeys, error) {
+	accountRoot, err := s.ledgerAccount(ctx, address.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get account: %w", err)
+	}
+	if accountRoot == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, address)
+	}
+
+	signerList, err := s.SignerListContext(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountKeys{
+		Account:        address,
+		RegularKey:     accountRoot.RegularKey,
+		MasterDisabled: accountRoot.Flags&lsfDisableMaster != 0,
+		SignerList:     signerList,
+	}, nil
+}
+
+// Check that the key a transaction is signed with may sign for the account
+func signingKeyCheck(address, signingPubKey string, regularKey types.Address, flags uint32) PreflightCheck {
+	signer, err := addresscodec.EncodeClassicAddressFromPublicKeyHex(signingPubKey)
+	if err != nil {
+		return PreflightCheck{Name: "signing key", Passed: false, Detail: fmt.Sprintf("invalid signing public key: %v", err)}
+	}
+
+	switch {
+	case signer == address && flags&lsfDisableMaster != 0:
+		return PreflightCheck{Name: "signing key", Passed: false, Detail: fmt.Sprintf("signed with the master key of %s, which is disabled", address)}
+	case signer == address:
+		return PreflightCheck{Name: "signing key", Passed: true, Detail: fmt.Sprintf("signed with the master key of %s", address)}
+	case signer == regularKey.String():
+		return PreflightCheck{Name: "signing key", Passed: true, Detail: fmt.Sprintf("signed with the regular key %s", signer)}
+	default:
+		return PreflightCheck{Name: "signing key", Passed: false, Detail: fmt.Sprintf("key %s is neither the master nor the regular key of %s", signer, address)}
+	}
+}
+
+// Check that an account keeps a regular key or signer list to sign with once its master key is disabled
+func (s *XRPLService) alternativeKeyCheck(ctx context.Context, address string, regularKey types.Address) (PreflightCheck, error) {
+	if regularKey != "" {
+		return PreflightCheck{Name: "alternative key", Passed: true, Detail: fmt.Sprintf("regular key %s can sign", regularKey)}, nil
+	}
+
+	signerList, err := s.SignerListContext(ctx, types.Address(address))
+	if err != nil {
+		return PreflightCheck{}, err
+	}
+	if signerList != nil {
+		return PreflightCheck{Name: "alternative key", Passed: true, Detail: fmt.Sprintf("signer list with quorum %d can sign", signerList.Quorum)}, nil
+	}
+	return PreflightCheck{Name: "alternative key", Passed: false, Detail: fmt.Sprintf("account %s has no regular key or signer list to sign with", address)}, nil
+}
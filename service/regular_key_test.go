@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/pkg/crypto"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSigningKeyCheck tests which keys may sign for an account
+func TestSigningKeyCheck(t *testing.T) {
+	master, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+	regular, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+	other, err := wallet.New(crypto.SECP256K1())
+	require.NoError(t, err)
+	address := master.ClassicAddress.String()
+
+	assert.True(t, signingKeyCheck(address, master.PublicKey, "", 0).Passed)
+	assert.False(t, signingKeyCheck(address, master.PublicKey, regular.ClassicAddress, lsfDisableMaster).Passed)
+	assert.True(t, signingKeyCheck(address, regular.PublicKey, regular.ClassicAddress, lsfDisableMaster).Passed)
+	assert.False(t, signingKeyCheck(address, other.PublicKey, regular.ClassicAddress, 0).Passed)
+
+	// A regular key restored for the account signs as the account
+	regularForAccount, err := wallet.FromSeed(regular.Seed, address)
+	require.NoError(t, err)
+	assert.Equal(t, master.ClassicAddress, regularForAccount.ClassicAddress)
+	assert.True(t, signingKeyCheck(address, regularForAccount.PublicKey, regular.ClassicAddress, 0).Passed)
+}
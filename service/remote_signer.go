@@ -0,0 +1,304 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/hash"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// Timeout of each request to the signing service
+const remoteSignerTimeout = 30 * time.Second
+
+// Key served by the signing service
+type remoteKey struct {
+	Address   types.Address `json:"address"`
+	PublicKey string        `json:"publicKey"`
+}
+
+// Signing request and response, transactions travel binary encoded so both sides sign exactly the same fields
+type signRequest struct {
+	TxBlob string `json:"txBlob"`
+}
+
+type signResponse struct {
+	TxBlob string `json:"txBlob"`
+	Hash   string `json:"hash"`
+}
+
+// RemoteSigner signs with a key held by a separate signing service, see NewSigningHandler.
+// The private key never enters the process using the signer.
+type RemoteSigner struct {
+	baseURL   string
+	token     string
+	key       string
+	address   types.Address
+	publicKey string
+	client    *http.Client
+}
+
+// NewRemoteSigner connects to the signing service at baseURL and looks up the account and public key of the named key
+func NewRemoteSigner(ctx context.Context, baseURL, token, key string) (*RemoteSigner, error) {
+	signer := &RemoteSigner{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		key:     key,
+		client:  &http.Client{Timeout: remoteSignerTimeout},
+	}
+
+	var served remoteKey
+	if err := signer.do(ctx, http.MethodGet, "", nil, &served); err != nil {
+		return nil, fmt.Errorf("unable to get remote key %s: %w", key, err)
+	}
+	if served.Address == "" || served.PublicKey == "" {
+		return nil, fmt.Errorf("signing service returned no address or public key for key %s", key)
+	}
+	signer.address, signer.publicKey = served.Address, served.PublicKey
+	return signer, nil
+}
+
+// Address returns the account the remote key signs for
+func (s *RemoteSigner) Address() types.Address {
+	return s.address
+}
+
+// PublicKey returns the public key of the remote key
+func (s *RemoteSigner) PublicKey() string {
+	return s.publicKey
+}
+
+// Sign has the signing service sign tx and checks that it signed tx unchanged
+func (s *RemoteSigner) Sign(ctx context.Context, tx transaction.FlatTransaction) (string, string, error) {
+	tx["SigningPubKey"] = s.publicKey
+
+	// A transaction re-signed after a sequence change must not carry its previous signature
+	unsignedTx := make(transaction.FlatTransaction, len(tx))
+	for field, value := range tx {
+		if field != "TxnSignature" {
+			unsignedTx[field] = value
+		}
+	}
+	unsigned, err := binarycodec.Encode(unsignedTx)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to encode transaction: %w", err)
+	}
+	expected, err := binarycodec.EncodeForSigning(unsignedTx)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to encode transaction: %w", err)
+	}
+
+	var signed signResponse
+	if err := s.do(ctx, http.MethodPost, "/sign", signRequest{TxBlob: unsigned}, &signed); err != nil {
+		return "", "", fmt.Errorf("remote key %s: %w", s.key, err)
+	}
+
+	signedTx, err := binarycodec.Decode(signed.TxBlob)
+	if err != nil {
+		return "", "", fmt.Errorf("signing service returned an invalid transaction: %w", err)
+	}
+	signature, _ := signedTx["TxnSignature"].(string)
+	if signature == "" {
+		return "", "", fmt.Errorf("signing service returned an unsigned transaction")
+	}
+	delete(signedTx, "TxnSignature")
+	if actual, err := binarycodec.EncodeForSigning(signedTx); err != nil || actual != expected {
+		return "", "", fmt.Errorf("signing service signed a different transaction than requested")
+	}
+
+	txHash, err := hash.SignTxBlob(signed.TxBlob)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to hash transaction: %w", err)
+	}
+	tx["TxnSignature"] = signature
+	return signed.TxBlob, txHash, nil
+}
+
+// Multisign has the signing service sign tx as a signer of the transaction's account and checks that
+// it added only its own signature to tx unchanged
+func (s *RemoteSigner) Multisign(ctx context.Context, tx transaction.FlatTransaction) (string, string, error) {
+	unsignedTx := make(transaction.FlatTransaction, len(tx))
+	for field, value := range tx {
+		if field != "Signers" {
+			unsignedTx[field] = value
+		}
+	}
+	unsigned, err := binarycodec.Encode(unsignedTx)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to encode transaction: %w", err)
+	}
+	expected, err := binarycodec.EncodeForSigning(unsignedTx)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to encode transaction: %w", err)
+	}
+
+	var signed signResponse
+	if err := s.do(ctx, http.MethodPost, "/multisign", signRequest{TxBlob: unsigned}, &signed); err != nil {
+		return "", "", fmt.Errorf("remote key %s: %w", s.key, err)
+	}
+
+	signedTx, err := binarycodec.Decode(signed.TxBlob)
+	if err != nil {
+		return "", "", fmt.Errorf("signing service returned an invalid transaction: %w", err)
+	}
+	txSigners, _ := signedTx["Signers"].([]any)
+	if len(txSigners) != 1 || signerAccount(txSigners[0]) != s.address.String() {
+		return "", "", fmt.Errorf("signing service returned no signature of account %s", s.address)
+	}
+	delete(signedTx, "Signers")
+	if actual, err := binarycodec.EncodeForSigning(signedTx); err != nil || actual != expected {
+		return "", "", fmt.Errorf("signing service signed a different transaction than requested")
+	}
+
+	txHash, err := hash.SignTxBlob(signed.TxBlob)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to hash transaction: %w", err)
+	}
+	tx["Signers"] = txSigners
+	return signed.TxBlob, txHash, nil
+}
+
+// Send a request about the signer's key to the signing service and decode its JSON response into out
+func (s *RemoteSigner) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+"/v1/keys/"+url.PathEscape(s.key)+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Not wrapped, so that transport errors of the signing service are never taken for a lost XRPL connection
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSignerUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&failure)
+		return fmt.Errorf("signing service returned %s: %s", resp.Status, failure.Error)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// NewSigningHandler serves signers by name to RemoteSigner clients over HTTP. Requests must carry token
+// as a bearer token, and each signer only signs transactions of its own account.
+func NewSigningHandler(token string, signers map[string]Signer) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v1/keys/{key}", func(w http.ResponseWriter, r *http.Request) {
+		signer, ok := signers[r.PathValue("key")]
+		if !ok {
+			writeSigningError(w, http.StatusNotFound, "unknown key")
+			return
+		}
+		writeSigningResponse(w, remoteKey{Address: signer.Address(), PublicKey: signer.PublicKey()})
+	})
+
+	mux.HandleFunc("POST /v1/keys/{key}/sign", func(w http.ResponseWriter, r *http.Request) {
+		key := r.PathValue("key")
+		signer, ok := signers[key]
+		if !ok {
+			writeSigningError(w, http.StatusNotFound, "unknown key")
+			return
+		}
+		var req signRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeSigningError(w, http.StatusBadRequest, "invalid request")
+			return
+		}
+
+		tx, err := binarycodec.Decode(req.TxBlob)
+		if err != nil {
+			writeSigningError(w, http.StatusBadRequest, "invalid transaction")
+			return
+		}
+		if account, _ := tx["Account"].(string); account != signer.Address().String() {
+			writeSigningError(w, http.StatusForbidden, fmt.Sprintf("key %s only signs for account %s", key, signer.Address()))
+			return
+		}
+
+		signed, err := SignOffline(r.Context(), signer, req.TxBlob)
+		if err != nil {
+			writeSigningError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		log.Printf("Signed %v %s for account %s with key %s", tx["TransactionType"], signed.Hash, signer.Address(), key)
+		writeSigningResponse(w, signResponse{TxBlob: signed.TxBlob, Hash: signed.Hash})
+	})
+
+	// A key multisigns for any account listing it as a signer, the ledger checks the signer list
+	mux.HandleFunc("POST /v1/keys/{key}/multisign", func(w http.ResponseWriter, r *http.Request) {
+		key := r.PathValue("key")
+		signer, ok := signers[key]
+		if !ok {
+			writeSigningError(w, http.StatusNotFound, "unknown key")
+			return
+		}
+		var req signRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeSigningError(w, http.StatusBadRequest, "invalid request")
+			return
+		}
+
+		signed, err := MultisignOffline(r.Context(), signer, req.TxBlob)
+		if err != nil {
+			writeSigningError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		txHash, err := hash.SignTxBlob(signed.TxBlob)
+		if err != nil {
+			writeSigningError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		log.Printf("Multisigned %v for account %v as signer %s with key %s", signed.Transaction["TransactionType"], signed.Transaction["Account"], signer.Address(), key)
+		writeSigningResponse(w, signResponse{TxBlob: signed.TxBlob, Hash: txHash})
+	})
+
+	// Every request must carry the token
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			writeSigningError(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// Write a JSON response of the signing service
+func writeSigningResponse(w http.ResponseWriter, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Write a JSON error of the signing service
+func writeSigningError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/pkg/crypto"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRemoteSigner tests signing through the signing service matches signing in memory
+func TestRemoteSigner(t *testing.T) {
+	key, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+	server := httptest.NewServer(NewSigningHandler("token", map[string]Signer{"issuer": NewWalletSigner(&key)}))
+	defer server.Close()
+
+	ctx := context.Background()
+	signer, err := NewRemoteSigner(ctx, server.URL, "token", "issuer")
+	require.NoError(t, err)
+	assert.Equal(t, key.ClassicAddress, signer.Address())
+	assert.Equal(t, key.PublicKey, signer.PublicKey())
+
+	newTx := func(account string) transaction.FlatTransaction {
+		return transaction.FlatTransaction{
+			"TransactionType":    "AccountSet",
+			"Account":            account,
+			"Fee":                "12",
+			"Sequence":           uint32(7),
+			"LastLedgerSequence": uint32(1000),
+		}
+	}
+
+	remoteTx := newTx(key.ClassicAddress.String())
+	remoteBlob, remoteHash, err := signer.Sign(ctx, remoteTx)
+	require.NoError(t, err)
+	localBlob, localHash, err := key.Sign(newTx(key.ClassicAddress.String()))
+	require.NoError(t, err)
+	assert.Equal(t, localBlob, remoteBlob)
+	assert.Equal(t, localHash, remoteHash)
+	assert.NotEmpty(t, remoteTx["TxnSignature"])
+
+	// Re-signing after a change replaces the previous signature
+	remoteTx["Sequence"] = uint32(8)
+	_, _, err = signer.Sign(ctx, remoteTx)
+	assert.NoError(t, err)
+
+	other, err := wallet.New(crypto.ED25519())
+	require.NoError(t, err)
+	_, _, err = signer.Sign(ctx, newTx(other.ClassicAddress.String()))
+	assert.ErrorContains(t, err, "only signs for account")
+
+	// Multisigning for another account adds only the remote key's signature
+	newMultisignTx := func() transaction.FlatTransaction {
+		tx := newTx(other.ClassicAddress.String())
+		tx["SigningPubKey"] = ""
+		return tx
+	}
+	multisignTx := newMultisignTx()
+	remoteBlob, remoteHash, err = signer.Multisign(ctx, multisignTx)
+	require.NoError(t, err)
+	localBlob, localHash, err = key.Multisign(newMultisignTx())
+	require.NoError(t, err)
+	assert.Equal(t, localBlob, remoteBlob)
+	assert.Equal(t, localHash, remoteHash)
+	assert.Len(t, multisignTx["Signers"], 1)
+
+	_, err = NewRemoteSigner(ctx, server.URL, "wrong", "issuer")
+	assert.ErrorContains(t, err, "401")
+	_, err = NewRemoteSigner(ctx, server.URL, "token", "unknown")
+	assert.ErrorContains(t, err, "404")
+
+	// An unreachable signing service is not mistaken for a lost XRPL connection
+	server.Close()
+	_, _, err = signer.Sign(ctx, newTx(key.ClassicAddress.String()))
+	assert.ErrorIs(t, err, ErrSignerUnavailable)
+	assert.NotErrorIs(t, classifyError(err), ErrConnectionLost)
+	assert.True(t, IsTemporary(err))
+}
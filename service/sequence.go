@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+// sequenceManager hands out account sequence numbers locally so that transactions
+// from the same account can be submitted concurrently without colliding.
+type sequenceManager struct {
+	mu       sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+// accountSequence tracks the next sequence number and in-flight transactions of a single account
+type accountSequence struct {
+	// Semaphore held while a sequence is allocated and its transaction submitted,
+	// so transactions reach the node in sequence order
+	sem chan struct{}
+
+	mu      sync.Mutex
+	next    uint32
+	synced  bool
+	pending map[uint32]struct{}
+}
+
+// Create an empty sequence manager
+func newSequenceManager() *sequenceManager {
+	return &sequenceManager{accounts: make(map[string]*accountSequence)}
+}
+
+// Get the sequence tracker of an account, creating it on first use
+func (m *sequenceManager) account(address string) *accountSequence {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seq, ok := m.accounts[address]
+	if !ok {
+		seq = &accountSequence{
+			sem:     make(chan struct{}, 1),
+			pending: make(map[uint32]struct{}),
+		}
+		m.accounts[address] = seq
+	}
+	return seq
+}
+
+// Acquire the right to allocate and submit the next sequence, giving up when ctx is done
+func (a *accountSequence) lock(ctx context.Context) error {
+	select {
+	case a.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release the right to allocate and submit
+func (a *accountSequence) unlock() {
+	<-a.sem
+}
+
+// Return the next sequence, loading it with fetch when the local state is not in sync with the ledger.
+// Must be called with the lock held; the sequence is only used up once commit is called.
+func (a *accountSequence) allocate(ctx context.Context, fetch func(ctx context.Context) (uint32, error)) (uint32, error) {
+	a.mu.Lock()
+	synced, next := a.synced, a.next
+	a.mu.Unlock()
+	if synced {
+		return next, nil
+	}
+
+	next, err := fetch(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	a.mu.Lock()
+	a.next, a.synced = next, true
+	a.mu.Unlock()
+	return next, nil
+}
+
+// Mark a sequence as used by a submitted transaction that is awaiting validation.
+// span is the number of sequences the transaction uses up, more than one for TicketCreate.
+func (a *accountSequence) commit(seq, span uint32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.next = seq + span
+	a.pending[seq] = struct{}{}
+}
+
+// Reload the sequence from the ledger on next allocation
+func (a *accountSequence) resync() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.synced = false
+}
+
+// Record the final outcome of a submitted transaction. When its sequence was not consumed the
+// account has a gap: if later transactions are still in flight the caller must fill the gap,
+// which done reports by returning true; otherwise the sequence is reloaded from the ledger.
+func (a *accountSequence) done(seq uint32, consumed bool) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.pending, seq)
+	if consumed {
+		return false
+	}
+	for pending := range a.pending {
+		if pending > seq {
+			return true
+		}
+	}
+	a.synced = false
+	return false
+}
+
+// Forget a submitted transaction whose outcome is unknown, e.g. because waiting for it was cancelled
+func (a *accountSequence) forget(seq uint32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.pending, seq)
+}
+
+// Get the next sequence number of an account from the current open ledger
+func (s *XRPLService) accountSequence(ctx context.Context, address string) (uint32, error) {
+	var resp *account.InfoResponse
+	_, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		var err error
+		resp, err = client.GetAccountInfo(&account.InfoRequest{
+			Account:     types.Address(address),
+			LedgerIndex: common.LedgerTitle("current"),
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.AccountData.Sequence, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAccountSequence tests local sequence allocation and gap handling
+func TestAccountSequence(t *testing.T) {
+	fetches := 0
+	fetch := func(ctx context.Context) (uint32, error) {
+		fetches++
+		return 10, nil
+	}
+
+	seqs := newSequenceManager().account("rAccount")
+	ctx := context.Background()
+
+	// Sequences are fetched once and then handed out locally
+	for want := uint32(10); want < 13; want++ {
+		seq, err := seqs.allocate(ctx, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, want, seq)
+		seqs.commit(seq, 1)
+	}
+	assert.Equal(t, 1, fetches)
+
+	// A consumed sequence leaves no gap
+	assert.False(t, seqs.done(10, true))
+
+	// An unused sequence with later transactions in flight must be filled
+	assert.True(t, seqs.done(11, false))
+
+	// An unused last sequence reloads from the ledger
+	assert.False(t, seqs.done(12, false))
+	seq, err := seqs.allocate(ctx, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(10), seq)
+	assert.Equal(t, 2, fetches)
+}
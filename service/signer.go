@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+)
+
+// Signer signs transactions for an account. Implementations decide where the private key lives:
+// in memory, in the local keystore or in a separate signing service.
+type Signer interface {
+	// Address returns the account the signer signs for
+	Address() types.Address
+	// PublicKey returns the hex encoded key signatures verify against, the account's master or regular key
+	PublicKey() string
+	// Sign sets SigningPubKey and TxnSignature on tx and returns the signed blob and its hash
+	Sign(ctx context.Context, tx transaction.FlatTransaction) (txBlob string, hash string, err error)
+	// Multisign sets Signers on tx to this signer's signature as one of the signers of the transaction's account
+	// and returns the partially signed blob and its hash
+	Multisign(ctx context.Context, tx transaction.FlatTransaction) (txBlob string, hash string, err error)
+}
+
+// WalletSigner signs with a wallet held in memory
+type WalletSigner struct {
+	wallet *wallet.Wallet
+}
+
+// NewWalletSigner creates a signer for an in-memory wallet. A wallet with only an address
+// serves as the account of write operations prepared for offline signing.
+func NewWalletSigner(wallet *wallet.Wallet) *WalletSigner {
+	return &WalletSigner{wallet: wallet}
+}
+
+// Address returns the account the wallet signs for
+func (s *WalletSigner) Address() types.Address {
+	return s.wallet.ClassicAddress
+}
+
+// PublicKey returns the public key of the wallet
+func (s *WalletSigner) PublicKey() string {
+	return s.wallet.PublicKey
+}
+
+// Sign signs tx with the wallet's private key
+func (s *WalletSigner) Sign(_ context.Context, tx transaction.FlatTransaction) (string, string, error) {
+	return s.wallet.Sign(tx)
+}
+
+// Multisign signs tx with the wallet's private key as a signer of the transaction's account
+func (s *WalletSigner) Multisign(_ context.Context, tx transaction.FlatTransaction) (string, string, error) {
+	return s.wallet.Multisign(tx)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+// ledger_accept admin request, closing the current ledger of a rippled running in standalone mode
+type ledgerAcceptRequest struct {
+	common.BaseRequest
+}
+
+func (*ledgerAcceptRequest) Method() string {
+	return "ledger_accept"
+}
+
+func (*ledgerAcceptRequest) Validate() error {
+	return nil
+}
+
+// Closes ledgers at a fixed interval, so transactions sent to a standalone rippled get validated
+type ledgerCloser struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Start closing ledgers every interval until stop is called
+func startLedgerCloser(conn *ConnectionManager, interval time.Duration) *ledgerCloser {
+	ctx, cancel := context.WithCancel(context.Background())
+	closer := &ledgerCloser{cancel: cancel, done: make(chan struct{})}
+
+	go func() {
+		defer close(closer.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		failing := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			_, err := conn.Do(ctx, func(client *websocket.Client) error {
+				_, err := client.Request(&ledgerAcceptRequest{})
+				return err
+			})
+			// Report the first failure of a run only, the node may be restarting
+			if err != nil && !failing && ctx.Err() == nil {
+				log.Printf("Warning: unable to close ledger, is the node a standalone rippled with admin access? %v", err)
+			}
+			failing = err != nil
+		}
+	}()
+	return closer
+}
+
+// Stop closing ledgers
+func (c *ledgerCloser) stop() {
+	c.cancel()
+	<-c.done
+}
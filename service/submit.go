@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+const (
+	// Interval between checks while waiting for a transaction to be validated
+	validationPollInterval = time.Second
+	// Number of ledgers after the current validated ledger in which a transaction may be included
+	lastLedgerOffset uint32 = 20
+)
+
+// Signed transaction awaiting validation
+type submission struct {
+	account            string
+	blob               string
+	lastLedgerSequence uint32
+	sequence           uint32           // Locally allocated sequence, zero if set by the caller
+	span               uint32           // Number of sequences the transaction uses up
+	accountSeq         *accountSequence // Sequence tracker of the account, nil if the sequence was set by the caller
+}
+
+// SubmitTransaction calls SubmitTransactionContext with a background context
+func (s *XRPLService) SubmitTransaction(signer Signer, flattenedTx transaction.FlatTransaction) (*TxResult, error) {
+	return s.SubmitTransactionContext(context.Background(), signer, flattenedTx)
+}
+
+// SubmitTransactionContext autofills, signs and submits a transaction and tracks it until its outcome is final.
+// Unless the transaction sets a Sequence or TicketSequence, its sequence is allocated locally so that
+// concurrent transactions from the same account do not collide.
+// Transient submission results are resubmitted until the transaction is validated or its
+// LastLedgerSequence passes, in which case the error matches ErrNotValidated. If ctx is done first,
+// the tentative result is returned with an error matching both ErrNotValidated and ctx.Err().
+// In dry-run mode the transaction is signed and checked but not submitted, in prepare-only mode it is
+// autofilled and returned unsigned. On mainnet transactions are only submitted by services returned
+// by WithMainnetConfirmed, otherwise the error matches ErrMainnetNotConfirmed.
+func (s *XRPLService) SubmitTransactionContext(ctx context.Context, signer Signer, flattenedTx transaction.FlatTransaction) (*TxResult, error) {
+	if s.dryRun {
+		return s.simulate(ctx, signer, flattenedTx)
+	}
+	if s.prepareOnly {
+		return s.prepare(ctx, flattenedTx)
+	}
+	if err := s.checkWriteAllowed(); err != nil {
+		return nil, err
+	}
+
+	sub, result, err := s.submit(ctx, signer, flattenedTx)
+	if err != nil {
+		return result, err
+	}
+
+	err = s.awaitValidation(ctx, sub, result)
+	if sub.accountSeq != nil {
+		switch {
+		case result.Validated:
+			sub.accountSeq.done(sub.sequence, true)
+		case result.Final:
+			// Expired without being applied, later transactions of the account are stuck behind this sequence.
+			// A no-op only fills a single sequence, wider gaps resolve once the later transactions expire.
+			if sub.accountSeq.done(sub.sequence, false) && sub.span == 1 {
+				s.fillSequenceGap(ctx, signer, sub)
+			}
+		default:
+			sub.accountSeq.forget(sub.sequence)
+		}
+	}
+	return result, err
+}
+
+// Allocate a sequence unless the caller set one, then autofill, sign and submit the transaction.
+// Submissions with locally allocated sequences are serialized per account so they reach the node in order.
+func (s *XRPLService) submit(ctx context.Context, signer Signer, flattenedTx transaction.FlatTransaction) (*submission, *TxResult, error) {
+	account, _ := flattenedTx["Account"].(string)
+	_, hasSequence := flattenedTx["Sequence"]
+	_, hasTicket := flattenedTx["TicketSequence"]
+	if account == "" || hasSequence || hasTicket {
+		return s.signAndSubmit(ctx, signer, flattenedTx, &submission{account: account})
+	}
+
+	// TicketCreate uses up one sequence for itself and one for each ticket
+	span := uint32(1)
+	if ticketCount, ok := flattenedTx["TicketCount"].(uint32); ok {
+		span += ticketCount
+	}
+
+	accountSeq := s.sequences.account(account)
+	if err := accountSeq.lock(ctx); err != nil {
+		return nil, nil, err
+	}
+	defer accountSeq.unlock()
+
+	for attempt := 1; ; attempt++ {
+		seq, err := accountSeq.allocate(ctx, func(ctx context.Context) (uint32, error) {
+			return s.accountSequence(ctx, account)
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to get account sequence: %w", err)
+		}
+		flattenedTx["Sequence"] = seq
+
+		sub, result, err := s.signAndSubmit(ctx, signer, flattenedTx, &submission{account: account, sequence: seq, span: span, accountSeq: accountSeq})
+		switch {
+		case err == nil:
+			accountSeq.commit(seq, span)
+		case result == nil:
+			// The node may or may not have received the transaction
+			accountSeq.resync()
+		case result.EngineResult == "tefPAST_SEQ" && attempt == 1:
+			// The sequence was used outside this service, reload it and try once more
+			accountSeq.resync()
+			continue
+		}
+		return sub, result, err
+	}
+}
+
+// Autofill, sign and submit a transaction. Transactions rejected by the node return a final TransactionError.
+func (s *XRPLService) signAndSubmit(ctx context.Context, signer Signer, flattenedTx transaction.FlatTransaction, sub *submission) (*submission, *TxResult, error) {
+	result := &TxResult{}
+
+	_, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		return s.autofill(client, flattenedTx, result)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sub.lastLedgerSequence, _ = flattenedTx["LastLedgerSequence"].(uint32)
+
+	// Sign without holding the connection, keystore and remote signers take a while
+	blob, hash, err := signer.Sign(ctx, flattenedTx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to sign transaction: %w", err)
+	}
+	sub.blob, result.Hash = blob, hash
+
+	submitResponse, node, err := s.submitBlob(ctx, sub.blob)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.Node = node
+	result.Fee, _ = flattenedTx["Fee"].(string)
+	result.Sequence, _ = flattenedTx["Sequence"].(uint32)
+	result.TicketSequence, _ = flattenedTx["TicketSequence"].(uint32)
+	return sub, result, submitOutcome(result, submitResponse)
+}
+
+// Submit a signed transaction blob to a node of the configured network, returning the preliminary result
+// and the node it was submitted to
+func (s *XRPLService) submitBlob(ctx context.Context, blob string) (*requests.SubmitResponse, string, error) {
+	var submitResponse *requests.SubmitResponse
+	node, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		if err := s.checkNodeNetwork(client); err != nil {
+			return err
+		}
+		var err error
+		submitResponse, err = client.SubmitTxBlob(blob, false)
+		if err != nil {
+			return fmt.Errorf("unable to submit transaction: %w", err)
+		}
+		return nil
+	})
+	return submitResponse, node, err
+}
+
+// Record the preliminary result of a submission. Transactions rejected by the node return a final TransactionError.
+func submitOutcome(result *TxResult, submitResponse *requests.SubmitResponse) error {
+	result.EngineResult = submitResponse.EngineResult
+	result.ResultMessage = resultMessage(submitResponse.EngineResult, submitResponse.EngineResultMessage)
+
+	// Malformed and failed transactions are never applied to a ledger
+	if isRejected(submitResponse.EngineResult) {
+		result.Final = true
+		return &TransactionError{
+			Hash:         result.Hash,
+			EngineResult: result.EngineResult,
+			Message:      result.ResultMessage,
+			Final:        true,
+		}
+	}
+	return nil
+}
+
+// Set LastLedgerSequence, pick the fee according to the fee policy and autofill the remaining fields.
+// Must be called within conn.Do.
+func (s *XRPLService) autofill(client *websocket.Client, flattenedTx transaction.FlatTransaction, result *TxResult) error {
+	// Bound the ledgers the transaction can be included in so its outcome becomes final
+	if _, ok := flattenedTx["LastLedgerSequence"]; !ok {
+		validatedLedger, err := client.GetLedgerIndex()
+		if err != nil {
+			return fmt.Errorf("unable to get validated ledger: %w", err)
+		}
+		flattenedTx["LastLedgerSequence"] = validatedLedger.Uint32() + lastLedgerOffset
+	}
+
+	// Pick the fee according to the fee policy unless the caller set one
+	if _, ok := flattenedTx["Fee"]; !ok {
+		fee, openLedgerFee, err := s.feePolicy.fee(client)
+		if err != nil {
+			return fmt.Errorf("unable to get fee: %w", err)
+		}
+		// Multisigned transactions pay the fee once for the transaction and once per signature
+		if s.multisigners > 0 {
+			fee *= uint64(1 + s.multisigners)
+		}
+		flattenedTx["Fee"] = strconv.FormatUint(fee, 10)
+		if openLedgerFee > 0 {
+			result.OpenLedgerFee = strconv.FormatUint(openLedgerFee, 10)
+		}
+	}
+	fee, _ := flattenedTx["Fee"].(string)
+	if err := s.feePolicy.check(fee); err != nil {
+		return err
+	}
+
+	// Autofill transaction
+	if err := client.Autofill(&flattenedTx); err != nil {
+		return fmt.Errorf("unable to autofill transaction: %w", err)
+	}
+	return nil
+}
+
+// Poll a submitted transaction until it is validated or can no longer be included in a ledger
+func (s *XRPLService) awaitValidation(ctx context.Context, sub *submission, result *TxResult) error {
+	resubmit := isRetryable(result.EngineResult)
+	ticker := time.NewTicker(validationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ErrNotValidated, ctx.Err())
+		}
+
+		txResponse, validatedLedger, err := s.transactionStatus(ctx, result.Hash)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("%w: %w", ErrNotValidated, ctx.Err())
+			}
+			return fmt.Errorf("unable to get transaction status: %w", err)
+		}
+
+		if txResponse != nil && txResponse.Validated {
+			return finalizeResult(result, txResponse)
+		}
+
+		// The transaction can no longer be included once the validated ledger passes its LastLedgerSequence
+		if validatedLedger.Uint32() > sub.lastLedgerSequence {
+			result.Final = true
+			return fmt.Errorf("%w: %w", ErrNotValidated, &TransactionError{
+				Hash:         result.Hash,
+				EngineResult: result.EngineResult,
+				Message:      fmt.Sprintf("not validated before LastLedgerSequence %d", sub.lastLedgerSequence),
+				Final:        true,
+			})
+		}
+
+		// Queued or fee-starved transactions are resubmitted until they make it into a ledger
+		if resubmit {
+			response, _, err := s.submitBlob(ctx, sub.blob)
+			// Past-sequence results on resubmission usually mean the original was applied, keep polling
+			if err == nil && !isRejected(response.EngineResult) {
+				result.EngineResult = response.EngineResult
+				result.ResultMessage = resultMessage(response.EngineResult, response.EngineResultMessage)
+				resubmit = isRetryable(response.EngineResult)
+			}
+		}
+	}
+}
+
+// Fill the sequence left unused by an expired transaction with a no-op AccountSet so that
+// later transactions from the account can be applied; reload the sequence if that fails
+func (s *XRPLService) fillSequenceGap(ctx context.Context, signer Signer, sub *submission) {
+	noop := &transaction.AccountSet{
+		BaseTx: transaction.BaseTx{
+			Account:  types.Address(sub.account),
+			Sequence: sub.sequence,
+		},
+	}
+	if _, err := s.SubmitTransactionContext(ctx, signer, noop.Flatten()); err != nil {
+		log.Printf("Unable to fill sequence gap %d of account %s: %v", sub.sequence, sub.account, err)
+		sub.accountSeq.resync()
+	}
+}
+
+// Look up a transaction and the latest validated ledger index; a transaction the node has not seen yet returns nil
+func (s *XRPLService) transactionStatus(ctx context.Context, txHash string) (*requests.TxResponse, common.LedgerIndex, error) {
+	var txResponse *requests.TxResponse
+	var validatedLedger common.LedgerIndex
+	_, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		res, err := client.Request(&requests.TxRequest{Transaction: txHash})
+		if err != nil && !isXRPLError(err, "txnNotFound") {
+			return err
+		}
+		if err == nil {
+			if err := res.GetResult(&txResponse); err != nil {
+				return err
+			}
+		}
+
+		validatedLedger, err = client.GetLedgerIndex()
+		return err
+	})
+	return txResponse, validatedLedger, err
+}
+
+// Fill the final outcome of a validated transaction from its metadata
+func finalizeResult(result *TxResult, txResponse *requests.TxResponse) error {
+	result.Validated = true
+	result.Final = true
+	result.LedgerIndex = txResponse.LedgerIndex.Uint32()
+
+	meta, err := decodeMeta(txResponse.Meta)
+	if err != nil {
+		return fmt.Errorf("unable to decode transaction metadata: %w", err)
+	}
+	// The final result may differ from the preliminary one returned on submission
+	if meta.TransactionResult != result.EngineResult || result.ResultMessage == "" {
+		result.ResultMessage = resultMessage(meta.TransactionResult, "")
+	}
+	result.EngineResult = meta.TransactionResult
+	result.TrustLines = affectedTrustLines(meta)
+
+	balanceChanges, err := transaction.GetBalanceChanges(meta)
+	if err == nil {
+		result.BalanceChanges = balanceChanges
+	}
+
+	if result.EngineResult != "tesSUCCESS" {
+		return &TransactionError{
+			Hash:         result.Hash,
+			EngineResult: result.EngineResult,
+			Message:      result.ResultMessage,
+			Final:        true,
+		}
+	}
+	return nil
+}
+
+// Decode raw transaction metadata
+func decodeMeta(raw any) (*transaction.TxObjMeta, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta transaction.TxObjMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// Malformed (tem) and failed (tef) transactions are rejected without being applied
+func isRejected(engineResult string) bool {
+	return strings.HasPrefix(engineResult, "tem") || strings.HasPrefix(engineResult, "tef")
+}
+
+// Retry (ter) and local (tel) results are transient, the transaction may still be applied if resubmitted
+func isRetryable(engineResult string) bool {
+	return strings.HasPrefix(engineResult, "ter") || strings.HasPrefix(engineResult, "tel")
+}
+
+// Check whether err is an XRPL error response of the given type, e.g. txnNotFound
+func isXRPLError(err error, errorType string) bool {
+	var xrplErr *websocket.ErrorWebsocketClientXrplResponse
+	return errors.As(err, &xrplErr) && xrplErr.Type == errorType
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEngineResultClassification tests which submission results are final and which are resubmitted
+func TestEngineResultClassification(t *testing.T) {
+	tests := []struct {
+		engineResult string
+		rejected     bool
+		retryable    bool
+	}{
+		{"tesSUCCESS", false, false},
+		{"tecPATH_DRY", false, false},
+		{"tecNO_LINE", false, false},
+		{"terQUEUED", false, true},
+		{"telINSUF_FEE_P", false, true},
+		{"tefPAST_SEQ", true, false},
+		{"temBAD_AMOUNT", true, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.rejected, isRejected(tt.engineResult), tt.engineResult)
+		assert.Equal(t, tt.retryable, isRetryable(tt.engineResult), tt.engineResult)
+	}
+}
+
+// TestFinalizeResult tests reading the final outcome and balance changes from transaction metadata
+func TestFinalizeResult(t *testing.T) {
+	meta := map[string]any{
+		"TransactionResult": "tecPATH_DRY",
+		"AffectedNodes": []any{
+			map[string]any{
+				"ModifiedNode": map[string]any{
+					"LedgerEntryType": "AccountRoot",
+					"FinalFields":     map[string]any{"Account": "rPEPPER7kfTD9w2To4CQk6UCfuHM9c6GDY", "Balance": "99999988"},
+					"PreviousFields":  map[string]any{"Balance": "100000000"},
+				},
+			},
+		},
+	}
+
+	result := &TxResult{Hash: "ABC"}
+	err := finalizeResult(result, &requests.TxResponse{Meta: meta, LedgerIndex: 100, Validated: true})
+
+	var txErr *TransactionError
+	require.ErrorAs(t, err, &txErr)
+	assert.True(t, txErr.Final)
+	assert.Equal(t, "tecPATH_DRY", result.EngineResult)
+	assert.True(t, result.Validated)
+	assert.Equal(t, uint32(100), result.LedgerIndex)
+	require.Len(t, result.BalanceChanges, 1)
+	assert.Equal(t, "-0.000012", result.BalanceChanges[0].Balances[0].Value)
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+// ticketPool tracks the tickets each account has available for new transactions
+type ticketPool struct {
+	mu       sync.Mutex
+	accounts map[string]*accountTickets
+}
+
+// accountTickets holds the tickets of a single account
+type accountTickets struct {
+	loaded    bool
+	available []uint32 // Unused tickets in ascending order
+	inUse     map[uint32]struct{}
+}
+
+// Create an empty ticket pool
+func newTicketPool() *ticketPool {
+	return &ticketPool{accounts: make(map[string]*accountTickets)}
+}
+
+// Get the tickets of an account, creating them on first use. Must be called with the lock held.
+func (p *ticketPool) account(address string) *accountTickets {
+	tickets, ok := p.accounts[address]
+	if !ok {
+		tickets = &accountTickets{inUse: make(map[uint32]struct{})}
+		p.accounts[address] = tickets
+	}
+	return tickets
+}
+
+// Take the lowest available ticket, loading the tickets with load when none are known
+func (p *ticketPool) acquire(ctx context.Context, address string, load func(ctx context.Context) ([]uint32, error)) (uint32, error) {
+	p.mu.Lock()
+	tickets := p.account(address)
+	needsLoad := !tickets.loaded || len(tickets.available) == 0
+	p.mu.Unlock()
+
+	if needsLoad {
+		ledgerTickets, err := load(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("unable to load tickets: %w", err)
+		}
+		p.set(address, ledgerTickets)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(tickets.available) == 0 {
+		return 0, ErrNoTickets
+	}
+	ticket := tickets.available[0]
+	tickets.available = tickets.available[1:]
+	tickets.inUse[ticket] = struct{}{}
+	return ticket, nil
+}
+
+// Replace the available tickets of an account with the tickets found in the ledger, skipping tickets in use
+func (p *ticketPool) set(address string, ledgerTickets []uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tickets := p.account(address)
+	tickets.available = tickets.available[:0]
+	for _, ticket := range ledgerTickets {
+		if _, ok := tickets.inUse[ticket]; !ok {
+			tickets.available = append(tickets.available, ticket)
+		}
+	}
+	sort.Slice(tickets.available, func(i, j int) bool { return tickets.available[i] < tickets.available[j] })
+	tickets.loaded = true
+}
+
+// Add newly created tickets to the pool
+func (p *ticketPool) add(address string, created []uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tickets := p.account(address)
+	tickets.available = append(tickets.available, created...)
+	sort.Slice(tickets.available, func(i, j int) bool { return tickets.available[i] < tickets.available[j] })
+}
+
+// Return a ticket that was not used up to the pool
+func (p *ticketPool) release(address string, ticket uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tickets := p.account(address)
+	delete(tickets.inUse, ticket)
+	tickets.available = append(tickets.available, ticket)
+	sort.Slice(tickets.available, func(i, j int) bool { return tickets.available[i] < tickets.available[j] })
+}
+
+// Drop a ticket that was used up. If its outcome is unknown the pool is reloaded on next use.
+func (p *ticketPool) consume(address string, ticket uint32, known bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tickets := p.account(address)
+	delete(tickets.inUse, ticket)
+	if !known {
+		tickets.loaded = false
+	}
+}
+
+// Snapshot of the available tickets of an account
+func (p *ticketPool) available(address string) []uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]uint32{}, p.account(address).available...)
+}
+
+// CreateTickets calls CreateTicketsContext with a background context
+func (s *XRPLService) CreateTickets(signer Signer, count uint32) (*TxResult, []uint32, error) {
+	return s.CreateTicketsContext(context.Background(), signer, count)
+}
+
+// CreateTicketsContext sets aside count sequence numbers of the signer's account as tickets and adds them to the ticket pool
+func (s *XRPLService) CreateTicketsContext(ctx context.Context, signer Signer, count uint32) (*TxResult, []uint32, error) {
+	ticketCreate := &transaction.TicketCreate{
+		BaseTx: transaction.BaseTx{
+			Account: signer.Address(),
+		},
+		TicketCount: count,
+	}
+	if _, err := ticketCreate.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	// Autofill, sign, submit and track transaction to its final outcome
+	result, err := s.SubmitTransactionContext(ctx, signer, ticketCreate.Flatten())
+	if err != nil {
+		return result, nil, fmt.Errorf("unable to create tickets: %w", err)
+	}
+	if result.DryRun || result.Prepared {
+		return result, nil, nil
+	}
+
+	// The new tickets take the sequence numbers following the TicketCreate transaction
+	created := make([]uint32, 0, count)
+	for i := uint32(1); i <= count; i++ {
+		created = append(created, result.Sequence+i)
+	}
+	s.tickets.add(string(signer.Address()), created)
+
+	return result, created, nil
+}
+
+// Tickets calls TicketsContext with a background context
+func (s *XRPLService) Tickets(address types.Address) ([]uint32, error) {
+	return s.TicketsContext(context.Background(), address)
+}
+
+// TicketsContext reloads the tickets of an account from the ledger and returns those available for new transactions
+func (s *XRPLService) TicketsContext(ctx context.Context, address types.Address) ([]uint32, error) {
+	ledgerTickets, err := s.loadTickets(ctx, string(address))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account tickets: %w", err)
+	}
+	s.tickets.set(string(address), ledgerTickets)
+
+	return s.tickets.available(string(address)), nil
+}
+
+// Get the sequence numbers of all tickets an account owns in the ledger
+func (s *XRPLService) loadTickets(ctx context.Context, address string) ([]uint32, error) {
+	var tickets []uint32
+	var marker any
+	for {
+		var resp *account.ObjectsResponse
+		_, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+			var err error
+			resp, err = client.GetAccountObjects(&account.ObjectsRequest{
+				Account: types.Address(address),
+				Type:    account.TicketObject,
+				Marker:  marker,
+			})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range resp.AccountObjects {
+			if ticket, ok := object["TicketSequence"].(float64); ok {
+				tickets = append(tickets, uint32(ticket))
+			}
+		}
+
+		if resp.Marker == nil {
+			return tickets, nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// Submit a transaction using one of the account's tickets instead of its next sequence number,
+// so it does not have to wait for other transactions from the account
+func (s *XRPLService) submitWithTicket(ctx context.Context, signer Signer, flattenedTx transaction.FlatTransaction) (*TxResult, error) {
+	address, _ := flattenedTx["Account"].(string)
+	ticket, err := s.tickets.acquire(ctx, address, func(ctx context.Context) ([]uint32, error) {
+		return s.loadTickets(ctx, address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	flattenedTx["Sequence"] = uint32(0)
+	flattenedTx["TicketSequence"] = ticket
+
+	result, err := s.SubmitTransactionContext(ctx, signer, flattenedTx)
+	switch {
+	case result != nil && result.DryRun:
+		s.tickets.release(address, ticket)
+	case result != nil && result.Validated:
+		s.tickets.consume(address, ticket, true)
+	case result != nil && result.Final && result.EngineResult != "tefNO_TICKET":
+		// Rejected or expired without being applied, the ticket can be used again
+		s.tickets.release(address, ticket)
+	default:
+		s.tickets.consume(address, ticket, false)
+	}
+	return result, err
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTicketPool tests handing out, returning and reloading account tickets
+func TestTicketPool(t *testing.T) {
+	ledgerTickets := []uint32{7, 5}
+	load := func(ctx context.Context) ([]uint32, error) {
+		return ledgerTickets, nil
+	}
+
+	pool := newTicketPool()
+	ctx := context.Background()
+
+	// Tickets are loaded on first use and handed out lowest first
+	ticket, err := pool.acquire(ctx, "rAccount", load)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), ticket)
+
+	// A ticket that was not used goes back to the pool
+	pool.release("rAccount", ticket)
+	assert.Equal(t, []uint32{5, 7}, pool.available("rAccount"))
+
+	// Reloading skips tickets in use
+	ticket, err = pool.acquire(ctx, "rAccount", load)
+	require.NoError(t, err)
+	pool.set("rAccount", ledgerTickets)
+	assert.Equal(t, []uint32{7}, pool.available("rAccount"))
+	pool.consume("rAccount", ticket, true)
+
+	// Running out of tickets reloads from the ledger before failing
+	ledgerTickets = nil
+	_, err = pool.acquire(ctx, "rAccount", load)
+	require.NoError(t, err)
+	_, err = pool.acquire(ctx, "rAccount", load)
+	assert.ErrorIs(t, err, ErrNoTickets)
+}
@@ -1,38 +1,101 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"sync"
 
 	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
-	"github.com/Peersyst/xrpl-go/xrpl/wallet"
 	"github.com/Peersyst/xrpl-go/xrpl/websocket"
 	"github.com/Sales-and-Customer-Success-department/xrpl-token-demo/config"
 )
 
 // XRPLService provides services for interacting with XRP Ledger
 type XRPLService struct {
-	client  *websocket.Client
-	nodeURL string
+	conn    *ConnectionManager
+	nodes   *config.NodePool
+	network config.Network
+	// Network IDs reported by configured nodes that are not public nodes of the network profile,
+	// keyed by client; nil when every configured node is a public node of the profile
+	nodeNetworkIDs *sync.Map
+	sequences      *sequenceManager
+	tickets        *ticketPool
+	feePolicy      FeePolicy
+	faucet         FaucetProvider
+	ledgerCloser   *ledgerCloser // Closes ledgers of a standalone rippled, nil when the network closes them
+	dryRun         bool
+	prepareOnly    bool
+	multisigners   uint32 // Number of signers transactions are prepared for, zero for single signing
+	// Whether transactions may be submitted to mainnet
+	mainnetConfirmed bool
 }
 
-// NewXRPLService creates a new XRPL service instance
+// NewXRPLService creates a new XRPL service instance.
+// The service keeps a persistent connection and is safe for concurrent use; call Close when done.
 func NewXRPLService(cfg *config.Config) *XRPLService {
-	return &XRPLService{
-		client:  cfg.Client,
-		nodeURL: cfg.NodeURL,
+	// Start node health checks for failover
+	cfg.Nodes.Start()
+
+	s := &XRPLService{
+		conn:      NewConnectionManager(cfg.Nodes),
+		nodes:     cfg.Nodes,
+		network:   cfg.Network,
+		sequences: newSequenceManager(),
+		tickets:   newTicketPool(),
+		feePolicy: FeePolicy{
+			FixedDrops: cfg.FeeDrops,
+			Multiplier: cfg.FeeMultiplier,
+			MaxDrops:   cfg.MaxFeeDrops,
+		},
 	}
-}
 
-// Ensure client connection
-func (s *XRPLService) ensureConnected() error {
-	if !s.client.IsConnected() {
-		if err := s.client.Connect(); err != nil {
-			return fmt.Errorf("unable to connect to XRP Ledger: %w", err)
+	for _, url := range cfg.NodeURLs {
+		if !cfg.Network.Includes(url) {
+			s.nodeNetworkIDs = &sync.Map{}
+			break
 		}
 	}
-	return nil
+
+	faucet, err := newFaucetProvider(s, cfg)
+	if err != nil {
+		log.Printf("Warning: %v, accounts cannot be funded", err)
+	}
+	s.faucet = faucet
+
+	if cfg.LedgerAcceptInterval > 0 {
+		s.ledgerCloser = startLedgerCloser(s.conn, cfg.LedgerAcceptInterval)
+	}
+	return s
+}
+
+// Close closes the connection to the XRP Ledger and stops node health checks
+func (s *XRPLService) Close() error {
+	if s.ledgerCloser != nil {
+		s.ledgerCloser.stop()
+	}
+	s.nodes.Stop()
+	return s.conn.Close()
+}
+
+// FeePolicy returns the fee policy applied to submitted transactions
+func (s *XRPLService) FeePolicy() FeePolicy {
+	return s.feePolicy
+}
+
+// ActiveNode returns the URL of the XRPL node currently serving requests
+func (s *XRPLService) ActiveNode() string {
+	return s.conn.Node()
+}
+
+// NodeStatus returns the health of every configured node, refreshing it first if requested
+func (s *XRPLService) NodeStatus(refresh bool) []config.NodeStatus {
+	if refresh {
+		s.nodes.CheckHealth()
+	}
+	return s.nodes.Status()
 }
 
 // Account setting flags
@@ -61,13 +124,12 @@ func stringToHex(s string) string {
 }
 
 // Configure issuer account settings
-func (s *XRPLService) ConfigureIssuerAccount(issuerWallet *wallet.Wallet, options *AccountSetFlags) (string, error) {
-	// Ensure client is connected
-	if err := s.ensureConnected(); err != nil {
-		return "", err
-	}
-	defer s.client.Disconnect()
+func (s *XRPLService) ConfigureIssuerAccount(issuer Signer, options *AccountSetFlags) (*TxResult, error) {
+	return s.ConfigureIssuerAccountContext(context.Background(), issuer, options)
+}
 
+// Configure issuer account settings, honoring cancellation and deadlines of ctx
+func (s *XRPLService) ConfigureIssuerAccountContext(ctx context.Context, issuer Signer, options *AccountSetFlags) (*TxResult, error) {
 	// Use default options if none provided
 	if options == nil {
 		defaultOptions := AccountSetFlags{
@@ -97,7 +159,7 @@ func (s *XRPLService) ConfigureIssuerAccount(issuerWallet *wallet.Wallet, option
 	// Configure issuer account settings
 	issuerAccountSet := &transaction.AccountSet{
 		BaseTx: transaction.BaseTx{
-			Account: issuerWallet.ClassicAddress,
+			Account: issuer.Address(),
 		},
 		TickSize:     types.TickSize(options.TickSize),         // Set tick size
 		TransferRate: types.TransferRate(options.TransferRate), // Set transfer rate
@@ -124,40 +186,22 @@ func (s *XRPLService) ConfigureIssuerAccount(issuerWallet *wallet.Wallet, option
 		issuerAccountSet.SetAsfAllowTrustLineClawback()
 	}
 
-	// Flatten and autofill transaction
-	flattenedTx := issuerAccountSet.Flatten()
-	err := s.client.Autofill(&flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to autofill transaction: %w", err)
-	}
-
-	// Sign transaction
-	txBlob, _, err := issuerWallet.Sign(flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to sign transaction: %w", err)
-	}
-
-	// Submit transaction and wait
-	response, err := s.client.SubmitTxBlobAndWait(txBlob, false)
+	// Autofill, sign, submit and track transaction to its final outcome
+	result, err := s.SubmitTransactionContext(ctx, issuer, issuerAccountSet.Flatten())
 	if err != nil {
-		return "", fmt.Errorf("unable to submit transaction: %w", err)
-	}
-
-	if !response.Validated {
-		return "", fmt.Errorf("issuer account settings configuration failed")
+		return result, fmt.Errorf("issuer account settings configuration failed: %w", err)
 	}
 
-	return response.Hash.String(), nil
+	return result, nil
 }
 
 // Configure distributor account settings
-func (s *XRPLService) ConfigureDistributorAccount(distributorWallet *wallet.Wallet, options *AccountSetFlags) (string, error) {
-	// Ensure client is connected
-	if err := s.ensureConnected(); err != nil {
-		return "", err
-	}
-	defer s.client.Disconnect()
+func (s *XRPLService) ConfigureDistributorAccount(distributor Signer, options *AccountSetFlags) (*TxResult, error) {
+	return s.ConfigureDistributorAccountContext(context.Background(), distributor, options)
+}
 
+// Configure distributor account settings, honoring cancellation and deadlines of ctx
+func (s *XRPLService) ConfigureDistributorAccountContext(ctx context.Context, distributor Signer, options *AccountSetFlags) (*TxResult, error) {
 	// Use default options if none provided
 	if options == nil {
 		defaultOptions := AccountSetFlags{
@@ -185,7 +229,7 @@ func (s *XRPLService) ConfigureDistributorAccount(distributorWallet *wallet.Wall
 	// Configure distributor account settings
 	distributorAccountSet := &transaction.AccountSet{
 		BaseTx: transaction.BaseTx{
-			Account: distributorWallet.ClassicAddress,
+			Account: distributor.Address(),
 		},
 		Domain: types.Domain(domainHex), // Set domain
 	}
@@ -210,30 +254,13 @@ func (s *XRPLService) ConfigureDistributorAccount(distributorWallet *wallet.Wall
 		distributorAccountSet.SetAsfAllowTrustLineClawback()
 	}
 
-	// Flatten and autofill transaction
-	flattenedTx := distributorAccountSet.Flatten()
-	err := s.client.Autofill(&flattenedTx)
+	// Autofill, sign, submit and track transaction to its final outcome
+	result, err := s.SubmitTransactionContext(ctx, distributor, distributorAccountSet.Flatten())
 	if err != nil {
-		return "", fmt.Errorf("unable to autofill transaction: %w", err)
+		return result, fmt.Errorf("distributor account settings configuration failed: %w", err)
 	}
 
-	// Sign transaction
-	txBlob, _, err := distributorWallet.Sign(flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to sign transaction: %w", err)
-	}
-
-	// Submit transaction and wait
-	response, err := s.client.SubmitTxBlobAndWait(txBlob, false)
-	if err != nil {
-		return "", fmt.Errorf("unable to submit transaction: %w", err)
-	}
-
-	if !response.Validated {
-		return "", fmt.Errorf("distributor account settings configuration failed")
-	}
-
-	return response.Hash.String(), nil
+	return result, nil
 }
 
 // Trust line options
@@ -241,25 +268,25 @@ type TrustLineOptions struct {
 	IssuerAddress types.Address `json:"issuerAddress"` // Issuer address
 	TokenName     string        `json:"tokenName"`     // Token name
 	Amount        string        `json:"amount"`        // Trust limit
+	UseTicket     bool          `json:"useTicket"`     // Use a ticket instead of the next sequence number
 }
 
 // Create trust line
-func (s *XRPLService) CreateTrustLine(wallet *wallet.Wallet, options *TrustLineOptions) (string, error) {
-	// Ensure client is connected
-	if err := s.ensureConnected(); err != nil {
-		return "", err
-	}
-	defer s.client.Disconnect()
+func (s *XRPLService) CreateTrustLine(signer Signer, options *TrustLineOptions) (*TxResult, error) {
+	return s.CreateTrustLineContext(context.Background(), signer, options)
+}
 
+// Create trust line, honoring cancellation and deadlines of ctx
+func (s *XRPLService) CreateTrustLineContext(ctx context.Context, signer Signer, options *TrustLineOptions) (*TxResult, error) {
 	// Return error if no options provided
 	if options == nil {
-		return "", fmt.Errorf("trust line options must be provided")
+		return nil, fmt.Errorf("trust line options must be provided")
 	}
 
 	// Create trust line from distributor account to issuer
 	trustSet := &transaction.TrustSet{
 		BaseTx: transaction.BaseTx{
-			Account: wallet.ClassicAddress,
+			Account: signer.Address(),
 		},
 		LimitAmount: types.IssuedCurrencyAmount{
 			Currency: options.TokenName,
@@ -268,128 +295,59 @@ func (s *XRPLService) CreateTrustLine(wallet *wallet.Wallet, options *TrustLineO
 		},
 	}
 
-	// Flatten and autofill transaction
-	flattenedTx := trustSet.Flatten()
-	err := s.client.Autofill(&flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to autofill transaction: %w", err)
-	}
-
-	// Sign transaction
-	txBlob, _, err := wallet.Sign(flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to sign transaction: %w", err)
+	// Autofill, sign, submit and track transaction to its final outcome
+	var result *TxResult
+	var err error
+	if options.UseTicket {
+		result, err = s.submitWithTicket(ctx, signer, trustSet.Flatten())
+	} else {
+		result, err = s.SubmitTransactionContext(ctx, signer, trustSet.Flatten())
 	}
-
-	// Submit transaction and wait
-	response, err := s.client.SubmitTxBlobAndWait(txBlob, false)
 	if err != nil {
-		return "", fmt.Errorf("unable to submit transaction: %w", err)
-	}
-
-	if !response.Validated {
-		return "", fmt.Errorf("unable to create trust line")
+		return result, fmt.Errorf("unable to create trust line: %w", err)
 	}
 
-	return response.Hash.String(), nil
+	return result, nil
 }
 
 // Freeze trust line
-func (s *XRPLService) FreezeTrustLine(wallet *wallet.Wallet, trustlineAddress types.Address, tokenName string) (string, error) {
-	// Ensure client is connected
-	if err := s.ensureConnected(); err != nil {
-		return "", err
-	}
-	defer s.client.Disconnect()
-
-	// Create TrustSet transaction to freeze trust line
-	trustSet := &transaction.TrustSet{
-		BaseTx: transaction.BaseTx{
-			Account: wallet.ClassicAddress,
-		},
-		LimitAmount: types.IssuedCurrencyAmount{
-			Currency: tokenName,
-			Issuer:   trustlineAddress,
-			Value:    "0", // Keep current limit when freezing, using 0 as placeholder, will maintain existing limit
-		},
-	}
-
-	// Set freeze flag
-	trustSet.SetSetFreezeFlag()
-
-	// Flatten and autofill transaction
-	flattenedTx := trustSet.Flatten()
-	err := s.client.Autofill(&flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to autofill transaction: %w", err)
-	}
-
-	// Sign transaction
-	txBlob, _, err := wallet.Sign(flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to sign transaction: %w", err)
-	}
+func (s *XRPLService) FreezeTrustLine(signer Signer, trustlineAddress types.Address, tokenName string) (*TxResult, error) {
+	return s.FreezeTrustLineContext(context.Background(), signer, trustlineAddress, tokenName)
+}
 
-	// Submit transaction and wait
-	response, err := s.client.SubmitTxBlobAndWait(txBlob, false)
+// Freeze trust line, honoring cancellation and deadlines of ctx
+func (s *XRPLService) FreezeTrustLineContext(ctx context.Context, signer Signer, trustlineAddress types.Address, tokenName string) (*TxResult, error) {
+	result, err := s.updateTrustLineFlags(ctx, signer, trustlineAddress, tokenName, func(line *TrustLine, trustSet *transaction.TrustSet) error {
+		trustSet.SetSetFreezeFlag()
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("unable to submit transaction: %w", err)
-	}
-
-	if !response.Validated {
-		return "", fmt.Errorf("unable to freeze trust line")
+		return result, fmt.Errorf("unable to freeze trust line: %w", err)
 	}
 
-	return response.Hash.String(), nil
+	return result, nil
 }
 
 // Unfreeze trust line
-func (s *XRPLService) UnfreezeTrustLine(wallet *wallet.Wallet, trustlineAddress types.Address, tokenName string) (string, error) {
-	// Ensure client is connected
-	if err := s.ensureConnected(); err != nil {
-		return "", err
-	}
-	defer s.client.Disconnect()
-
-	// Create TrustSet transaction to unfreeze trust line
-	trustSet := &transaction.TrustSet{
-		BaseTx: transaction.BaseTx{
-			Account: wallet.ClassicAddress,
-		},
-		LimitAmount: types.IssuedCurrencyAmount{
-			Currency: tokenName,
-			Issuer:   trustlineAddress,
-			Value:    "0", // Keep current limit when unfreezing, using 0 as placeholder, will maintain existing limit
-		},
-	}
-
-	// Set unfreeze flag
-	trustSet.SetClearFreezeFlag()
-
-	// Flatten and autofill transaction
-	flattenedTx := trustSet.Flatten()
-	err := s.client.Autofill(&flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to autofill transaction: %w", err)
-	}
-
-	// Sign transaction
-	txBlob, _, err := wallet.Sign(flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to sign transaction: %w", err)
-	}
+func (s *XRPLService) UnfreezeTrustLine(signer Signer, trustlineAddress types.Address, tokenName string) (*TxResult, error) {
+	return s.UnfreezeTrustLineContext(context.Background(), signer, trustlineAddress, tokenName)
+}
 
-	// Submit transaction and wait
-	response, err := s.client.SubmitTxBlobAndWait(txBlob, false)
+// Unfreeze trust line, honoring cancellation and deadlines of ctx. A deep freeze of the line is lifted as well.
+func (s *XRPLService) UnfreezeTrustLineContext(ctx context.Context, signer Signer, trustlineAddress types.Address, tokenName string) (*TxResult, error) {
+	result, err := s.updateTrustLineFlags(ctx, signer, trustlineAddress, tokenName, func(line *TrustLine, trustSet *transaction.TrustSet) error {
+		trustSet.SetClearFreezeFlag()
+		// A deep frozen line cannot be unfrozen without lifting the deep freeze
+		if line.DeepFreeze {
+			trustSet.SetClearDeepFreezeFlag()
+		}
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("unable to submit transaction: %w", err)
+		return result, fmt.Errorf("unable to unfreeze trust line: %w", err)
 	}
 
-	if !response.Validated {
-		return "", fmt.Errorf("unable to unfreeze trust line")
-	}
-
-	return response.Hash.String(), nil
+	return result, nil
 }
 
 // Token transfer options
@@ -399,25 +357,32 @@ type TransferTokenOptions struct {
 	TokenName       string        `json:"tokenName"`       // Token name
 	Amount          string        `json:"amount"`          // Transfer amount
 	SendMax         string        `json:"sendMax"`         // (Optional) Maximum amount sender is willing to spend
+	UseTicket       bool          `json:"useTicket"`       // Use a ticket instead of the next sequence number
 }
 
 // TransferToken transfers tokens
-func (s *XRPLService) TransferToken(senderWallet *wallet.Wallet, options *TransferTokenOptions) (string, error) {
-	// Ensure client is connected
-	if err := s.ensureConnected(); err != nil {
-		return "", err
-	}
-	defer s.client.Disconnect()
+func (s *XRPLService) TransferToken(sender Signer, options *TransferTokenOptions) (*TxResult, error) {
+	return s.TransferTokenContext(context.Background(), sender, options)
+}
 
+// TransferTokenContext transfers tokens, honoring cancellation and deadlines of ctx
+func (s *XRPLService) TransferTokenContext(ctx context.Context, sender Signer, options *TransferTokenOptions) (*TxResult, error) {
 	// Return error if no options provided
 	if options == nil {
-		return "", fmt.Errorf("token transfer options must be provided")
+		return nil, fmt.Errorf("token transfer options must be provided")
+	}
+
+	// Fail fast instead of paying the fee for a payment the ledger refuses, dry runs report it as a preflight check
+	if !s.dryRun && !s.prepareOnly {
+		if err := s.checkGlobalFreeze(ctx, sender.Address(), options.ReceiverAddress, options.IssuerAddress); err != nil {
+			return nil, err
+		}
 	}
 
 	// Send tokens from sender to receiver
 	payment := &transaction.Payment{
 		BaseTx: transaction.BaseTx{
-			Account: senderWallet.ClassicAddress,
+			Account: sender.Address(),
 		},
 		Amount: types.IssuedCurrencyAmount{
 			Currency: options.TokenName,
@@ -436,30 +401,19 @@ func (s *XRPLService) TransferToken(senderWallet *wallet.Wallet, options *Transf
 		}
 	}
 
-	// Flatten and autofill transaction
-	flattenedTx := payment.Flatten()
-	err := s.client.Autofill(&flattenedTx)
-	if err != nil {
-		return "", fmt.Errorf("unable to autofill transaction: %w", err)
+	// Autofill, sign, submit and track transaction to its final outcome
+	var result *TxResult
+	var err error
+	if options.UseTicket {
+		result, err = s.submitWithTicket(ctx, sender, payment.Flatten())
+	} else {
+		result, err = s.SubmitTransactionContext(ctx, sender, payment.Flatten())
 	}
-
-	// Sign transaction
-	txBlob, _, err := senderWallet.Sign(flattenedTx)
 	if err != nil {
-		return "", fmt.Errorf("unable to sign transaction: %w", err)
+		return result, fmt.Errorf("token payment failed: %w", err)
 	}
 
-	// Submit transaction and wait
-	response, err := s.client.SubmitTxBlobAndWait(txBlob, false)
-	if err != nil {
-		return "", fmt.Errorf("unable to submit transaction: %w", err)
-	}
-
-	if !response.Validated {
-		return "", fmt.Errorf("token payment failed")
-	}
-
-	return response.Hash.String(), nil
+	return result, nil
 }
 
 // TokenBalance structure represents token balance information
@@ -472,33 +426,54 @@ type TokenBalance struct {
 
 // GetTokenBalances gets the list of tokens and balances held by an account (simplified version, maintains backward compatibility)
 func (s *XRPLService) GetTokenBalances(holderAddress types.Address) ([]TokenBalance, error) {
-	// Ensure client is connected
-	if err := s.ensureConnected(); err != nil {
+	return s.GetTokenBalancesContext(context.Background(), holderAddress)
+}
+
+// GetTokenBalancesContext gets the list of tokens and balances held by an account, honoring cancellation and deadlines of ctx
+func (s *XRPLService) GetTokenBalancesContext(ctx context.Context, holderAddress types.Address) ([]TokenBalance, error) {
+	tokens, err := s.GetAccountTokensContext(ctx, holderAddress)
+	if err != nil {
 		return nil, err
 	}
-	defer s.client.Disconnect()
+	return tokens.Tokens, nil
+}
 
+// TokenBalancesResponse represents the response for getting the tokens held by an account
+type TokenBalancesResponse struct {
+	Account string         `json:"account"` // Queried account address
+	Tokens  []TokenBalance `json:"tokens"`  // Tokens and balances held
+	Node    string         `json:"node"`    // XRPL node that served the request
+}
+
+// GetAccountTokens gets the list of tokens and balances held by an account and the node that served it
+func (s *XRPLService) GetAccountTokens(holderAddress types.Address) (*TokenBalancesResponse, error) {
+	return s.GetAccountTokensContext(context.Background(), holderAddress)
+}
+
+// GetAccountTokensContext gets the list of tokens and balances held by an account and the node that served it,
+// honoring cancellation and deadlines of ctx
+func (s *XRPLService) GetAccountTokensContext(ctx context.Context, holderAddress types.Address) (*TokenBalancesResponse, error) {
 	// Create account trust lines request
 	req := &account.LinesRequest{
 		Account: holderAddress,
 	}
 
 	// Send request to get account trust lines
-	resp, err := s.client.GetAccountLines(req)
+	var resp *account.LinesResponse
+	node, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		var err error
+		resp, err = client.GetAccountLines(req)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account trust lines: %w", err)
 	}
 
-	// Return empty result if no trust lines
-	if len(resp.Lines) == 0 {
-		return []TokenBalance{}, nil
-	}
-
 	// Parse information for each trust line
-	var tokenBalances []TokenBalance
+	result := &TokenBalancesResponse{Account: holderAddress.String(), Tokens: []TokenBalance{}, Node: node}
 	for _, line := range resp.Lines {
 		// Create and add token balance information
-		tokenBalances = append(tokenBalances, TokenBalance{
+		result.Tokens = append(result.Tokens, TokenBalance{
 			TokenName:   line.Currency,
 			Issuer:      string(line.Account),
 			Balance:     line.Balance,
@@ -506,24 +481,26 @@ func (s *XRPLService) GetTokenBalances(holderAddress types.Address) ([]TokenBala
 		})
 	}
 
-	return tokenBalances, nil
+	return result, nil
 }
 
 // TrustLine structure represents detailed trust line information
 type TrustLine struct {
-	Account        string `json:"account"`         // Counterparty address
-	Balance        string `json:"balance"`         // Current balance (positive means holding tokens, negative means owed)
-	Currency       string `json:"currency"`        // Token code
-	Limit          string `json:"limit"`           // Maximum amount this account is willing to owe counterparty
-	LimitPeer      string `json:"limit_peer"`      // Maximum amount counterparty is willing to owe this account
-	QualityIn      uint32 `json:"quality_in"`      // Exchange rate for receiving balances
-	QualityOut     uint32 `json:"quality_out"`     // Exchange rate for sending balances
-	NoRipple       bool   `json:"no_ripple"`       // Whether this account has NoRipple flag enabled
-	NoRipplePeer   bool   `json:"no_ripple_peer"`  // Whether counterparty has NoRipple flag enabled
-	Authorized     bool   `json:"authorized"`      // Whether this account has authorized this trust line
-	PeerAuthorized bool   `json:"peer_authorized"` // Whether counterparty has authorized this trust line
-	Freeze         bool   `json:"freeze"`          // Whether this account has frozen this trust line
-	FreezePeer     bool   `json:"freeze_peer"`     // Whether counterparty has frozen this trust line
+	Account        string `json:"account"`          // Counterparty address
+	Balance        string `json:"balance"`          // Current balance (positive means holding tokens, negative means owed)
+	Currency       string `json:"currency"`         // Token code
+	Limit          string `json:"limit"`            // Maximum amount this account is willing to owe counterparty
+	LimitPeer      string `json:"limit_peer"`       // Maximum amount counterparty is willing to owe this account
+	QualityIn      uint32 `json:"quality_in"`       // Exchange rate for receiving balances
+	QualityOut     uint32 `json:"quality_out"`      // Exchange rate for sending balances
+	NoRipple       bool   `json:"no_ripple"`        // Whether this account has NoRipple flag enabled
+	NoRipplePeer   bool   `json:"no_ripple_peer"`   // Whether counterparty has NoRipple flag enabled
+	Authorized     bool   `json:"authorized"`       // Whether this account has authorized this trust line
+	PeerAuthorized bool   `json:"peer_authorized"`  // Whether counterparty has authorized this trust line
+	Freeze         bool   `json:"freeze"`           // Whether this account has frozen this trust line
+	FreezePeer     bool   `json:"freeze_peer"`      // Whether counterparty has frozen this trust line
+	DeepFreeze     bool   `json:"deep_freeze"`      // Whether this account has deep frozen this trust line, so it can neither send nor receive
+	DeepFreezePeer bool   `json:"deep_freeze_peer"` // Whether counterparty has deep frozen this trust line
 }
 
 // TrustLinesResponse represents the response for getting trust lines
@@ -531,54 +508,57 @@ type TrustLinesResponse struct {
 	Account   string      `json:"account"`   // Queried account address
 	Lines     []TrustLine `json:"lines"`     // Trust lines list
 	Validated bool        `json:"validated"` // Whether from validated ledger
+	Node      string      `json:"node"`      // XRPL node that served the request
 }
 
 // GetAllTrustLines gets all detailed trust line information for an account
 func (s *XRPLService) GetAllTrustLines(accountAddress types.Address) (*TrustLinesResponse, error) {
-	// Ensure client is connected
-	if err := s.ensureConnected(); err != nil {
-		return nil, err
-	}
-	defer s.client.Disconnect()
-
-	// Create account trust lines request
-	req := &account.LinesRequest{
-		Account: accountAddress,
-	}
+	return s.GetAllTrustLinesContext(context.Background(), accountAddress)
+}
 
-	// Send request to get account trust lines
-	resp, err := s.client.GetAccountLines(req)
+// GetAllTrustLinesContext gets all detailed trust line information for an account, honoring cancellation and deadlines of ctx
+func (s *XRPLService) GetAllTrustLinesContext(ctx context.Context, accountAddress types.Address) (*TrustLinesResponse, error) {
+	result, err := s.accountLines(ctx, accountAddress, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account trust lines: %w", err)
 	}
+	return result, nil
+}
 
-	// Build response result
-	result := &TrustLinesResponse{
-		Account:   string(accountAddress),
-		Lines:     make([]TrustLine, 0, len(resp.Lines)),
-		Validated: true, // Assume from validated ledger, should actually get from response
-	}
+// account_lines result, decoded into TrustLine for the deep freeze fields the client library does not know
+type accountLinesResult struct {
+	Lines     []TrustLine `json:"lines"`
+	Marker    any         `json:"marker,omitempty"`
+	Validated bool        `json:"validated"`
+}
 
-	// Convert trust line data
-	for _, line := range resp.Lines {
-		trustLine := TrustLine{
-			Account:        string(line.Account),
-			Balance:        line.Balance,
-			Currency:       line.Currency,
-			Limit:          line.Limit,
-			LimitPeer:      line.LimitPeer,
-			QualityIn:      uint32(line.QualityIn),
-			QualityOut:     uint32(line.QualityOut),
-			NoRipple:       line.NoRipple,
-			NoRipplePeer:   line.NoRipplePeer,
-			Authorized:     line.Authorized,
-			PeerAuthorized: line.PeerAuthorized,
-			Freeze:         line.Freeze,
-			FreezePeer:     line.FreezePeer,
+// Get the trust lines of an account, only those with peer if set, following all result pages
+func (s *XRPLService) accountLines(ctx context.Context, accountAddress, peer types.Address) (*TrustLinesResponse, error) {
+	var result *TrustLinesResponse
+	node, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		result = &TrustLinesResponse{Account: accountAddress.String(), Lines: []TrustLine{}, Validated: true}
+		req := &account.LinesRequest{Account: accountAddress, Peer: peer}
+		for {
+			res, err := client.Request(req)
+			if err != nil {
+				return err
+			}
+			var page accountLinesResult
+			if err := res.GetResult(&page); err != nil {
+				return err
+			}
+			result.Lines = append(result.Lines, page.Lines...)
+			result.Validated = result.Validated && page.Validated
+
+			if page.Marker == nil {
+				return nil
+			}
+			req.Marker = page.Marker
 		}
-
-		result.Lines = append(result.Lines, trustLine)
+	})
+	if err != nil {
+		return nil, err
 	}
-
+	result.Node = node
 	return result, nil
 }
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+)
+
+// TxResult is the outcome of a submitted transaction.
+// Write operations return it together with the error when a submitted transaction did not succeed.
+type TxResult struct {
+	Hash           string                              `json:"hash"`                     // Transaction hash
+	EngineResult   string                              `json:"engineResult"`             // Engine result code, e.g. tesSUCCESS or tecPATH_DRY
+	ResultMessage  string                              `json:"resultMessage"`            // Human-readable explanation of the engine result
+	Validated      bool                                `json:"validated"`                // Whether the transaction is in a validated ledger
+	Final          bool                                `json:"final"`                    // Whether the outcome can no longer change
+	LedgerIndex    uint32                              `json:"ledgerIndex"`              // Ledger the transaction was included in
+	Fee            string                              `json:"fee"`                      // Transaction cost paid in drops
+	OpenLedgerFee  string                              `json:"openLedgerFee,omitempty"`  // Open ledger fee in drops when the fee was chosen
+	Sequence       uint32                              `json:"sequence"`                 // Account sequence consumed by the transaction
+	TicketSequence uint32                              `json:"ticketSequence,omitempty"` // Ticket used in place of the sequence, if any
+	BalanceChanges []transaction.AccountBalanceChanges `json:"balanceChanges"`           // Balance changes from the transaction metadata
+	TrustLines     []AffectedTrustLine                 `json:"trustLines"`               // Trust lines created, modified or deleted
+	Node           string                              `json:"node"`                     // XRPL node the transaction was submitted to
+	DryRun         bool                                `json:"dryRun,omitempty"`         // Whether the transaction was only built and signed, not submitted
+	Prepared       bool                                `json:"prepared,omitempty"`       // Whether the transaction was only autofilled, to be signed offline
+	Transaction    transaction.FlatTransaction         `json:"transaction,omitempty"`    // Signed transaction JSON of a dry run
+	Blob           string                              `json:"blob,omitempty"`           // Signed transaction blob of a dry run
+	Checks         []PreflightCheck                    `json:"checks,omitempty"`         // Preflight checks of a dry run
+}
+
+// AffectedTrustLine describes a trust line touched by a transaction
+type AffectedTrustLine struct {
+	Change          string `json:"change"`                    // created, modified or deleted
+	Currency        string `json:"currency"`                  // Token code
+	LowAccount      string `json:"lowAccount"`                // Account with the lower address
+	HighAccount     string `json:"highAccount"`               // Account with the higher address
+	Balance         string `json:"balance"`                   // Balance from the low account's perspective
+	PreviousBalance string `json:"previousBalance,omitempty"` // Balance before the transaction, if it changed
+	LowLimit        string `json:"lowLimit"`                  // Limit set by the low account
+	HighLimit       string `json:"highLimit"`                 // Limit set by the high account
+	Flags           uint32 `json:"flags"`                     // RippleState flags (freeze, auth, no ripple)
+}
+
+// TransactionError reports a transaction that did not succeed
+type TransactionError struct {
+	Hash         string // Transaction hash
+	EngineResult string // Engine result code
+	Message      string // Engine result message, if known
+	Final        bool   // Whether the failure is final or the transaction may still succeed
+}
+
+func (e *TransactionError) Error() string {
+	msg := fmt.Sprintf("transaction %s failed with %s", e.Hash, e.EngineResult)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if !e.Final {
+		msg += " (tentative)"
+	}
+	return msg
+}
+
+// Explanations for the engine results most commonly seen when issuing and transferring tokens
+var resultMessages = map[string]string{
+	"tesSUCCESS":               "The transaction was applied.",
+	"tecDST_TAG_NEEDED":        "The destination account requires a destination tag.",
+	"tecFROZEN":                "The asset is subject to a global freeze.",
+	"tecINSUF_RESERVE_LINE":    "Insufficient XRP reserve to create the trust line.",
+	"tecINSUFFICIENT_RESERVE":  "Insufficient XRP reserve for the new ledger object.",
+	"tecNO_ALTERNATIVE_KEY":    "The account would be left without a key that can sign; set a regular key or signer list first.",
+	"tecNO_AUTH":               "The trust line is not authorized by the issuer.",
+	"tecNO_DST":                "The destination account does not exist.",
+	"tecNO_DST_INSUF_XRP":      "The destination account does not exist and the payment is too small to create it.",
+	"tecNO_LINE":               "The account has no trust line for this token.",
+	"tecNO_LINE_INSUF_RESERVE": "Insufficient XRP reserve to create the trust line.",
+	"tecNO_PERMISSION":         "The sender does not have permission to perform this operation.",
+	"tecPATH_DRY":              "The payment could not be delivered, usually because of a missing or frozen trust line.",
+	"tecPATH_PARTIAL":          "Only part of the amount could be delivered; check SendMax and transfer fees.",
+	"tecUNFUNDED_PAYMENT":      "The sender does not hold enough of the asset.",
+	"tefBAD_AUTH":              "The key used to sign is not authorized for this account.",
+	"tefMASTER_DISABLED":       "The master key is disabled for this account.",
+	"tefMAX_LEDGER":            "The LastLedgerSequence has already passed.",
+	"tefPAST_SEQ":              "The sequence number has already been used.",
+	"telINSUF_FEE_P":           "The fee is too low for the current server load.",
+	"temBAD_AMOUNT":            "The amount is invalid.",
+	"temBAD_CURRENCY":          "The currency code is invalid.",
+	"temDST_IS_SRC":            "The source and destination are the same account.",
+	"terINSUF_FEE_B":           "The account does not hold enough XRP to pay the fee.",
+	"terNO_ACCOUNT":            "The sending account does not exist.",
+	"terNO_AUTH":               "The trust line is not authorized by the issuer.",
+	"terNO_LINE":               "The account has no trust line for this token.",
+	"terPRE_SEQ":               "An earlier sequence number has not been applied yet.",
+	"terQUEUED":                "The transaction was queued for a later ledger.",
+}
+
+// Describe an engine result, preferring the message reported by the server
+func resultMessage(engineResult, serverMessage string) string {
+	if serverMessage != "" {
+		return serverMessage
+	}
+	return resultMessages[engineResult]
+}
+
+// Collect the trust lines created, modified or deleted by a transaction
+func affectedTrustLines(meta *transaction.TxObjMeta) []AffectedTrustLine {
+	var lines []AffectedTrustLine
+	for _, node := range meta.AffectedNodes {
+		var change string
+		var fields, previous ledger.FlatLedgerObject
+		switch {
+		case node.CreatedNode != nil && node.CreatedNode.LedgerEntryType == ledger.RippleStateEntry:
+			change, fields = "created", node.CreatedNode.NewFields
+		case node.ModifiedNode != nil && node.ModifiedNode.LedgerEntryType == ledger.RippleStateEntry:
+			change, fields, previous = "modified", node.ModifiedNode.FinalFields, node.ModifiedNode.PreviousFields
+		case node.DeletedNode != nil && node.DeletedNode.LedgerEntryType == ledger.RippleStateEntry:
+			change, fields = "deleted", node.DeletedNode.FinalFields
+		default:
+			continue
+		}
+
+		line := AffectedTrustLine{
+			Change:      change,
+			Currency:    amountField(fields, "Balance", "currency"),
+			LowAccount:  amountField(fields, "LowLimit", "issuer"),
+			HighAccount: amountField(fields, "HighLimit", "issuer"),
+			Balance:     amountField(fields, "Balance", "value"),
+			LowLimit:    amountField(fields, "LowLimit", "value"),
+			HighLimit:   amountField(fields, "HighLimit", "value"),
+		}
+		if flags, ok := fields["Flags"].(float64); ok {
+			line.Flags = uint32(flags)
+		}
+		if previous != nil {
+			line.PreviousBalance = amountField(previous, "Balance", "value")
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Read a key of an issued currency amount field from a ledger object
+func amountField(fields ledger.FlatLedgerObject, name, key string) string {
+	amount, ok := fields[name].(map[string]any)
+	if !ok {
+		return ""
+	}
+	value, _ := amount[key].(string)
+	return value
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAffectedTrustLines tests reading created and modified trust lines from transaction metadata
+func TestAffectedTrustLines(t *testing.T) {
+	meta, err := decodeMeta(map[string]any{
+		"TransactionResult": "tesSUCCESS",
+		"AffectedNodes": []any{
+			map[string]any{
+				"ModifiedNode": map[string]any{
+					"LedgerEntryType": "RippleState",
+					"FinalFields": map[string]any{
+						"Balance":   map[string]any{"currency": "USD", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "-15"},
+						"LowLimit":  map[string]any{"currency": "USD", "issuer": "rLowAccount", "value": "0"},
+						"HighLimit": map[string]any{"currency": "USD", "issuer": "rHighAccount", "value": "100"},
+						"Flags":     float64(0x00020000),
+					},
+					"PreviousFields": map[string]any{
+						"Balance": map[string]any{"currency": "USD", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "-5"},
+					},
+				},
+			},
+			map[string]any{
+				"ModifiedNode": map[string]any{
+					"LedgerEntryType": "AccountRoot",
+					"FinalFields":     map[string]any{"Account": "rLowAccount", "Balance": "99999988"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	lines := affectedTrustLines(meta)
+	require.Len(t, lines, 1)
+	assert.Equal(t, AffectedTrustLine{
+		Change:          "modified",
+		Currency:        "USD",
+		LowAccount:      "rLowAccount",
+		HighAccount:     "rHighAccount",
+		Balance:         "-15",
+		PreviousBalance: "-5",
+		LowLimit:        "0",
+		HighLimit:       "100",
+		Flags:           0x00020000,
+	}, lines[0])
+}
+
+// TestResultMessage tests preferring the server message over the built-in explanation
+func TestResultMessage(t *testing.T) {
+	assert.Equal(t, "from server", resultMessage("tecPATH_DRY", "from server"))
+	assert.NotEmpty(t, resultMessage("tecPATH_DRY", ""))
+	assert.Empty(t, resultMessage("tecUNKNOWN", ""))
+}
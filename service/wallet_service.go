@@ -1,46 +1,169 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Peersyst/xrpl-go/pkg/crypto"
+	"github.com/Peersyst/xrpl-go/xrpl/interfaces"
 	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
 	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
 )
 
+// Key algorithms of new wallets
+const (
+	AlgorithmED25519   = "ed25519"
+	AlgorithmSECP256K1 = "secp256k1"
+)
+
+// CreateAccountOptions selects how new wallets are generated
+type CreateAccountOptions struct {
+	Algorithm       string `json:"algorithm,omitempty"`       // ed25519 (default) or secp256k1, mnemonic wallets are always secp256k1
+	Mnemonic        bool   `json:"mnemonic,omitempty"`        // Generate a BIP39 mnemonic and derive the wallets from it
+	RestoreMnemonic string `json:"restoreMnemonic,omitempty"` // Derive the wallets from an existing mnemonic instead
+	DerivationPath  string `json:"derivationPath,omitempty"`  // BIP44 path of the first wallet, default m/44'/144'/0'/0/0
+	Count           int    `json:"count,omitempty"`           // Number of wallets derived from the mnemonic, counting up the last path index
+}
+
+// NewAccount is a wallet created by CreateAccountWithOptions
+type NewAccount struct {
+	Wallet         *wallet.Wallet
+	Mnemonic       string // Mnemonic the wallet is derived from, empty for wallets of a random seed
+	DerivationPath string // Path of the wallet within the mnemonic
+}
+
 // CreateAccount creates a new XRP account
 func (s *XRPLService) CreateAccount() (*wallet.Wallet, error) {
-	// Create new wallet
-	newWallet, err := wallet.New(crypto.ED25519())
+	accounts, err := s.CreateAccountWithOptions(nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create wallet: %w", err)
+		return nil, err
+	}
+	return accounts[0].Wallet, nil
+}
+
+// CreateAccountWithOptions creates new XRP accounts, from a random seed of the chosen algorithm or derived
+// from a new or restored mnemonic. Several accounts can only be derived from a mnemonic.
+func (s *XRPLService) CreateAccountWithOptions(options *CreateAccountOptions) ([]NewAccount, error) {
+	if options == nil {
+		options = &CreateAccountOptions{}
+	}
+	count := max(options.Count, 1)
+
+	mnemonic := options.RestoreMnemonic
+	if options.Mnemonic && mnemonic == "" {
+		var err error
+		if mnemonic, err = NewMnemonic(); err != nil {
+			return nil, fmt.Errorf("failed to create wallet: %w", err)
+		}
+	}
+
+	if mnemonic == "" {
+		if count > 1 || options.DerivationPath != "" {
+			return nil, fmt.Errorf("deriving accounts requires a mnemonic")
+		}
+
+		// Create new wallet
+		algorithm, err := keyAlgorithm(options.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+		newWallet, err := wallet.New(algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create wallet: %w", err)
+		}
+		return []NewAccount{{Wallet: &newWallet}}, nil
+	}
+
+	// BIP44 derivation only yields secp256k1 keys
+	if options.Algorithm != "" && options.Algorithm != AlgorithmSECP256K1 {
+		return nil, fmt.Errorf("mnemonic wallets use %s keys", AlgorithmSECP256K1)
+	}
+	path := options.DerivationPath
+	if path == "" {
+		path = DefaultDerivationPath
+	}
+
+	accounts := make([]NewAccount, 0, count)
+	for i := 0; i < count; i++ {
+		accountPath, err := nextDerivationPath(path, i)
+		if err != nil {
+			return nil, err
+		}
+		derived, err := WalletFromMnemonic(mnemonic, accountPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive wallet: %w", err)
+		}
+		accounts = append(accounts, NewAccount{Wallet: derived, Mnemonic: mnemonic, DerivationPath: accountPath})
 	}
+	return accounts, nil
+}
 
-	return &newWallet, nil
+// Get the key algorithm of a name, ed25519 if empty
+func keyAlgorithm(name string) (interfaces.CryptoImplementation, error) {
+	switch name {
+	case "", AlgorithmED25519:
+		return crypto.ED25519(), nil
+	case AlgorithmSECP256K1:
+		return crypto.SECP256K1(), nil
+	}
+	return nil, fmt.Errorf("unknown key algorithm %q, use %s or %s", name, AlgorithmED25519, AlgorithmSECP256K1)
 }
 
+// GetBalance gets the XRP balance of an account
 func (s *XRPLService) GetBalance(address types.Address) (string, error) {
-	// Ensure client is connected
-	if err := s.ensureConnected(); err != nil {
+	return s.GetBalanceContext(context.Background(), address)
+}
+
+// GetBalanceContext gets the XRP balance of an account, honoring cancellation and deadlines of ctx
+func (s *XRPLService) GetBalanceContext(ctx context.Context, address types.Address) (string, error) {
+	balance, err := s.GetAccountBalanceContext(ctx, address)
+	if err != nil {
 		return "", err
 	}
-	defer s.client.Disconnect()
+	return balance.Balance, nil
+}
+
+// BalanceResponse represents the response for getting the XRP balance of an account
+type BalanceResponse struct {
+	Account string `json:"account"` // Queried account address
+	Balance string `json:"balance"` // XRP balance
+	Node    string `json:"node"`    // XRPL node that served the request
+}
 
+// GetAccountBalance gets the XRP balance of an account and the node that served it
+func (s *XRPLService) GetAccountBalance(address types.Address) (*BalanceResponse, error) {
+	return s.GetAccountBalanceContext(context.Background(), address)
+}
+
+// GetAccountBalanceContext gets the XRP balance of an account and the node that served it, honoring cancellation and deadlines of ctx
+func (s *XRPLService) GetAccountBalanceContext(ctx context.Context, address types.Address) (*BalanceResponse, error) {
 	// Create account info request
 	req := &account.InfoRequest{
 		Account: address,
 	}
 
 	// Get account information
-	resp, err := s.client.GetAccountInfo(req)
+	var resp *account.InfoResponse
+	node, err := s.conn.Do(ctx, func(client *websocket.Client) error {
+		var err error
+		resp, err = client.GetAccountInfo(req)
+		return err
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get account info: %w", err)
+		return nil, fmt.Errorf("failed to get account info: %w", err)
 	}
 
-	// Extract XRP balance from result and convert to string (XRP is stored as drops in XRPL, 1 XRP = 1,000,000 drops)
-	balance := resp.AccountData.Balance
-	balanceInXRP := float64(balance) / 1000000.0
+	// Extract XRP balance from result and convert to string
+	return &BalanceResponse{
+		Account: address.String(),
+		Balance: formatXRP(uint64(resp.AccountData.Balance)),
+		Node:    node,
+	}, nil
+}
 
-	return fmt.Sprintf("%.6f XRP", balanceInXRP), nil
+// Format an amount of drops as XRP (XRP is stored as drops in XRPL, 1 XRP = 1,000,000 drops)
+func formatXRP(drops uint64) string {
+	return fmt.Sprintf("%.6f XRP", float64(drops)/1000000.0)
 }